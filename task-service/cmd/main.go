@@ -1,119 +1,400 @@
-package main
-
-import (
-	"context"
-	"fmt"
-	"log/slog"
-	"net/http"
-	"os"
-	"task-service/internal/config"
-	"task-service/internal/http/handlers/task/change"
-	"task-service/internal/http/handlers/task/delete"
-	"task-service/internal/http/handlers/task/get"
-	"task-service/internal/http/handlers/task/save"
-	"task-service/internal/lib/logger/sl"
-	"task-service/internal/lib/logger/sl/slogpretty"
-	"task-service/internal/repo/postgresql"
-	"task-service/internal/repo/redis"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-)
-
-// @title 			Task service API
-// @version 		1.0
-// @description 	This is a sample task service API.
-// @host 			localhost:8080
-// @BasePath 		/task
-func main() {
-	cfg := config.MustLoad()
-
-	// TODO: изменить на os.Stderr
-	// log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
-
-	log := setupPrettySlog()
-
-	log.Debug("Starting task-service...")
-
-	dbUrl := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		cfg.Database.User,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-		cfg.Database.SSLMode,
-	)
-
-	migrations, err := migrate.New(
-		"file://migrations",
-		dbUrl,
-	)
-
-	if err != nil {
-		log.Error("Failed to create migration instance", sl.Error(err))
-		os.Exit(1)
-	}
-
-	if err := migrations.Up(); err != migrate.ErrNoChange {
-		log.Error("Failed to apply migrations", sl.Error(err))
-		os.Exit(1)
-	}
-	log.Info("Migrations applied successfully")
-
-	db, err := postgresql.NewDb(dbUrl)
-	if err != nil {
-		log.Error("Failed to connect to the database", sl.Error(err))
-		os.Exit(1)
-	}
-	log.Info("Database connection established successfully")
-
-	rdb, err := redis.NewClient(context.Background(), cfg)
-	if err != nil {
-		log.Error("Failed to connect to Redis", sl.Error(err))
-		os.Exit(1)
-	}
-	log.Info("Redis connection established successfully")
-
-	router := chi.NewRouter()
-
-	router.Use(middleware.RequestID)
-	router.Use(middleware.Logger)
-	router.Use(middleware.Recoverer)
-	router.Use(middleware.URLFormat)
-
-	router.Post("/task", save.New(log, db, rdb))
-	router.Get("/task/{id}", get.New(log, db, rdb))
-	router.Delete("/task/{id}", delete.New(log, db, rdb))
-	router.Patch("/task/{id}", change.New(log, db, rdb))
-
-	log.Info("Starting service", slog.String("address", cfg.HTTPServer.Address))
-
-	srv := &http.Server{
-		Addr:         cfg.HTTPServer.Address,
-		Handler:      router,
-		ReadTimeout:  cfg.HTTPServer.Timeout,
-		WriteTimeout: cfg.HTTPServer.Timeout,
-		IdleTimeout:  cfg.HTTPServer.IddleTimeout,
-	}
-
-	log.Info("HTTP server started", slog.String("address", cfg.HTTPServer.Address))
-	log.Info("Waiting for requests...")
-	if err := srv.ListenAndServe(); err != nil {
-		log.Error("Failed to start service")
-	}
-}
-
-func setupPrettySlog() *slog.Logger {
-	opts := slogpretty.PrettyHandlerOptions{
-		SlogOpts: &slog.HandlerOptions{
-			Level: slog.LevelDebug,
-		},
-	}
-
-	handler := opts.NewPrettyHandler(os.Stdout)
-
-	return slog.New(handler)
-}
+package main
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"task-service/internal/config"
+	grpcTask "task-service/internal/grpc/handlers/task"
+	grpcServer "task-service/internal/grpc/server"
+	auditList "task-service/internal/http/handlers/audit/list"
+	"task-service/internal/http/handlers/auth/login"
+	"task-service/internal/http/handlers/auth/refresh"
+	"task-service/internal/http/handlers/auth/register"
+	"task-service/internal/http/handlers/health"
+	projectChange "task-service/internal/http/handlers/project/change"
+	projectDelete "task-service/internal/http/handlers/project/delete"
+	projectGet "task-service/internal/http/handlers/project/get"
+	projectList "task-service/internal/http/handlers/project/list"
+	projectSave "task-service/internal/http/handlers/project/save"
+	tagList "task-service/internal/http/handlers/tag/list"
+	"task-service/internal/http/handlers/task/batch"
+	"task-service/internal/http/handlers/task/block"
+	"task-service/internal/http/handlers/task/blockers"
+	"task-service/internal/http/handlers/task/change"
+	"task-service/internal/http/handlers/task/children"
+	"task-service/internal/http/handlers/task/delete"
+	"task-service/internal/http/handlers/task/events"
+	"task-service/internal/http/handlers/task/get"
+	"task-service/internal/http/handlers/task/history"
+	"task-service/internal/http/handlers/task/list"
+	"task-service/internal/http/handlers/task/move"
+	"task-service/internal/http/handlers/task/overdue"
+	"task-service/internal/http/handlers/task/parent"
+	"task-service/internal/http/handlers/task/reopen"
+	"task-service/internal/http/handlers/task/restore"
+	"task-service/internal/http/handlers/task/save"
+	"task-service/internal/http/handlers/task/search"
+	"task-service/internal/http/handlers/task/subtree"
+	"task-service/internal/http/handlers/task/trash"
+	"task-service/internal/http/handlers/task/unblock"
+	webhookChange "task-service/internal/http/handlers/webhook/change"
+	webhookDelete "task-service/internal/http/handlers/webhook/delete"
+	"task-service/internal/http/handlers/webhook/deliveries"
+	webhookGet "task-service/internal/http/handlers/webhook/get"
+	webhookList "task-service/internal/http/handlers/webhook/list"
+	"task-service/internal/http/handlers/webhook/retry"
+	webhookSave "task-service/internal/http/handlers/webhook/save"
+	"task-service/internal/http/handlers/ws"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/http/middleware/redact"
+	"task-service/internal/lib/hub"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/logger/sl/slogpretty"
+	"task-service/internal/lib/token"
+	"task-service/internal/lib/webhook"
+	"task-service/internal/lib/workflow"
+	"task-service/internal/repo/cache"
+	"task-service/internal/repo/postgresql"
+	"task-service/internal/repo/redis"
+	"task-service/internal/worker/delivery"
+	"task-service/internal/worker/purge"
+	"task-service/internal/worker/recurrence"
+	"task-service/internal/worker/relay"
+	_ "time/tzdata"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// @title 			Task service API
+// @version 		1.0
+// @description 	This is a sample task service API.
+// @host 			localhost:8080
+// @BasePath 		/
+// @securityDefinitions.apikey 	BearerAuth
+// @in 							header
+// @name 						Authorization
+// @description 				Access token from /auth/login as "Bearer <token>"
+func main() {
+	cfg := config.MustLoad()
+
+	// TODO: изменить на os.Stderr
+	// log := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	log := setupPrettySlog()
+
+	log.Debug("Starting task-service...")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	dbUrl := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
+		cfg.Database.User,
+		cfg.Database.Password,
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.Name,
+		cfg.Database.SSLMode,
+	)
+
+	migrations, err := migrate.New(
+		"file://migrations",
+		dbUrl,
+	)
+
+	if err != nil {
+		log.Error("Failed to create migration instance", sl.Error(err))
+		os.Exit(1)
+	}
+
+	if err := migrations.Up(); err != migrate.ErrNoChange {
+		log.Error("Failed to apply migrations", sl.Error(err))
+		os.Exit(1)
+	}
+	log.Info("Migrations applied successfully")
+
+	db, err := postgresql.NewDb(log, dbUrl, cfg.Database.Breaker)
+	if err != nil {
+		log.Error("Failed to connect to the database", sl.Error(err))
+		os.Exit(1)
+	}
+	log.Info("Database connection established successfully")
+
+	// Redis is only a cache: without it tasks are served from the database
+	// and Watch reconnects in the background.
+	rdb := redis.NewClient(log, cfg)
+	if err := rdb.Ping(context.Background()); err != nil {
+		log.Warn("Redis is unavailable, serving without cache", sl.Error(err))
+	} else {
+		log.Info("Redis connection established successfully")
+	}
+
+	// Background workers are stopped only after the servers have drained,
+	// so the events of the last requests are still relayed, and are waited
+	// for before the connections they use are closed.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	runWorker := func(run func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workersCtx)
+		}()
+	}
+
+	runWorker(func(ctx context.Context) {
+		rdb.Watch(ctx, cfg.Redis.HealthInterval)
+	})
+
+	tasks := cache.New(log, db, rdb, cfg.Redis)
+	runWorker(func(ctx context.Context) {
+		tasks.Run(ctx, rdb.SubscribeInvalidations(ctx, cfg.Redis.CacheChannel))
+	})
+
+	recurrenceWorker, err := recurrence.New(log, tasks, cfg.Recurrence)
+	if err != nil {
+		log.Error("Failed to create recurrence worker", sl.Error(err))
+		os.Exit(1)
+	}
+	runWorker(recurrenceWorker.Run)
+
+	runWorker(purge.New(log, db, cfg.Trash).Run)
+
+	runWorker(relay.New(log, db, cfg.Outbox, tasks.Invalidator(), rdb.Streams(cfg.Outbox.Stream, cfg.Outbox.MaxLen), rdb.Channel(cfg.Outbox.Channel)).Run)
+
+	eventHub := hub.New(log)
+	runWorker(func(ctx context.Context) {
+		eventHub.Run(ctx, rdb.SubscribeEvents(ctx, cfg.Outbox.Channel))
+	})
+
+	runWorker(delivery.New(log, db, webhook.NewClient(), cfg.Webhook).Run)
+
+	wf, err := workflow.New(cfg.Workflow.Transitions)
+	if err != nil {
+		log.Error("Invalid task status workflow", sl.Error(err))
+		os.Exit(1)
+	}
+
+	tokens, err := token.New(cfg.Auth.Secret, cfg.Auth.AccessTTL, cfg.Auth.RefreshTTL)
+	if err != nil {
+		log.Error("Failed to create token manager", sl.Error(err))
+		os.Exit(1)
+	}
+
+	router := chi.NewRouter()
+
+	router.Use(middleware.RequestID)
+	// Browsers pass the WebSocket access token in the query.
+	router.Use(redact.New("access_token"))
+	router.Use(middleware.Logger)
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.URLFormat)
+
+	router.Get("/health", health.New(log, db, rdb))
+
+	router.Post("/auth/register", register.New(log, db))
+	router.Post("/auth/login", login.New(log, db, tokens))
+	router.Post("/auth/refresh", refresh.New(log, tokens))
+
+	// Authenticates on its own: browsers cannot set headers on WebSockets.
+	router.Get("/ws", ws.New(log, tokens, tasks, eventHub, wf))
+
+	router.Group(func(r chi.Router) {
+		r.Use(auth.New(log, tokens))
+
+		r.Post("/task", save.New(log, tasks))
+		r.Get("/task", list.New(log, tasks))
+		r.Get("/task/search", search.New(log, tasks))
+		r.Get("/task/overdue", overdue.New(log, tasks))
+		r.Get("/task/events", events.New(log, tasks, eventHub))
+		r.Post("/task/batch", batch.New(log, tasks, wf))
+		r.Get("/task/trash", trash.New(log, tasks, cfg.Trash.Retention))
+		r.Get("/task/{id}", get.New(log, tasks))
+		r.Delete("/task/{id}", delete.New(log, tasks))
+		r.Patch("/task/{id}", change.New(log, tasks, wf))
+		r.Post("/task/{id}/reopen", reopen.New(log, tasks))
+		r.Post("/task/{id}/restore", restore.New(log, tasks))
+		r.Get("/task/{id}/history", history.New(log, db))
+		r.Get("/task/{id}/audit", auditList.NewForTask(log, db))
+		r.Post("/task/{id}/move", move.New(log, tasks))
+		r.Post("/task/{id}/parent", parent.New(log, tasks))
+		r.Get("/task/{id}/children", children.New(log, tasks))
+		r.Get("/task/{id}/subtree", subtree.New(log, tasks))
+		r.Get("/task/{id}/blockers", blockers.New(log, tasks))
+		r.Post("/task/{id}/blockers", block.New(log, tasks))
+		r.Delete("/task/{id}/blockers/{blocker_id}", unblock.New(log, tasks))
+
+		r.Post("/project", projectSave.New(log, tasks))
+		r.Get("/project", projectList.New(log, tasks))
+		r.Get("/project/{id}", projectGet.New(log, tasks))
+		r.Patch("/project/{id}", projectChange.New(log, tasks))
+		r.Delete("/project/{id}", projectDelete.New(log, tasks))
+		r.Get("/project/{id}/tasks", list.NewForProject(log, tasks))
+
+		r.Get("/tag", tagList.New(log, db))
+
+		r.Get("/audit", auditList.New(log, db))
+
+		r.Post("/webhook", webhookSave.New(log, db))
+		r.Get("/webhook", webhookList.New(log, db))
+		r.Get("/webhook/{id}", webhookGet.New(log, db))
+		r.Patch("/webhook/{id}", webhookChange.New(log, db))
+		r.Delete("/webhook/{id}", webhookDelete.New(log, db))
+		r.Get("/webhook/{id}/deliveries", deliveries.New(log, db))
+		r.Post("/webhook/{id}/deliveries/{delivery_id}/retry", retry.New(log, db))
+	})
+
+	grpcSrv := grpcServer.New(log, tokens, grpcTask.New(log, tasks, wf, eventHub))
+
+	listener, err := net.Listen("tcp", cfg.GRPCServer.Address)
+	if err != nil {
+		log.Error("Failed to listen for gRPC", sl.Error(err))
+		os.Exit(1)
+	}
+
+	serveErr := make(chan error, 3)
+
+	go func() {
+		log.Info("gRPC server started", slog.String("address", cfg.GRPCServer.Address))
+		if err := grpcSrv.Serve(listener); err != nil {
+			serveErr <- fmt.Errorf("gRPC: %w", err)
+		}
+	}()
+
+	log.Info("Starting service", slog.String("address", cfg.HTTPServer.Address))
+
+	srv := &http.Server{
+		Addr:         cfg.HTTPServer.Address,
+		Handler:      router,
+		ReadTimeout:  cfg.HTTPServer.Timeout,
+		WriteTimeout: cfg.HTTPServer.Timeout,
+		IdleTimeout:  cfg.HTTPServer.IddleTimeout,
+	}
+	// Event streams and board connections never end on their own: closing
+	// the hub ends them, the gRPC watches included, once no new ones can
+	// be opened.
+	srv.RegisterOnShutdown(eventHub.Close)
+
+	go func() {
+		log.Info("HTTP server started", slog.String("address", cfg.HTTPServer.Address))
+		log.Info("Waiting for requests...")
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("HTTP: %w", err)
+		}
+	}()
+
+	// The counters of /debug/vars include the command line and the cache
+	// statistics, so they are kept off the public address.
+	admin := http.NewServeMux()
+	admin.Handle("/debug/vars", expvar.Handler())
+	adminSrv := &http.Server{
+		Addr:         cfg.AdminServer.Address,
+		Handler:      admin,
+		ReadTimeout:  cfg.HTTPServer.Timeout,
+		WriteTimeout: cfg.HTTPServer.Timeout,
+	}
+
+	go func() {
+		log.Info("Admin server started", slog.String("address", cfg.AdminServer.Address))
+		if err := adminSrv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- fmt.Errorf("admin: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Info("Shutting down")
+	case err := <-serveErr:
+		log.Error("Failed to serve, shutting down", sl.Error(err))
+	}
+	// A second signal kills the service at once.
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTPServer.ShutdownTimeout)
+	defer cancel()
+
+	var servers sync.WaitGroup
+	servers.Add(3)
+
+	go func() {
+		defer servers.Done()
+		if err := adminSrv.Shutdown(shutdownCtx); err != nil {
+			adminSrv.Close()
+		}
+	}()
+
+	go func() {
+		defer servers.Done()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error("Failed to drain HTTP requests", sl.Error(err))
+			srv.Close()
+		}
+	}()
+
+	go func() {
+		defer servers.Done()
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			log.Error("Failed to drain gRPC calls", sl.Error(shutdownCtx.Err()))
+			grpcSrv.Stop()
+		}
+	}()
+
+	servers.Wait()
+	log.Info("Servers stopped")
+
+	stopWorkers()
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		log.Info("Background workers stopped")
+	case <-shutdownCtx.Done():
+		log.Error("Background workers did not stop in time", sl.Error(shutdownCtx.Err()))
+	}
+
+	// The cache goes before the database it caches.
+	if err := rdb.Close(); err != nil {
+		log.Error("Failed to close Redis connection", sl.Error(err))
+	}
+	if err := db.Close(); err != nil {
+		log.Error("Failed to close database connection", sl.Error(err))
+	}
+
+	log.Info("Service stopped")
+}
+
+func setupPrettySlog() *slog.Logger {
+	opts := slogpretty.PrettyHandlerOptions{
+		SlogOpts: &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+		},
+	}
+
+	handler := opts.NewPrettyHandler(os.Stdout)
+
+	return slog.New(handler)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+// AuditEvent records who changed a task, when, and which fields changed.
+type AuditEvent struct {
+	Id        int64
+	TaskId    uuid.UUID
+	OwnerId   uuid.UUID
+	Action    AuditAction
+	Actor     string
+	RequestId string
+	Changes   map[string]FieldChange
+	CreatedAt time.Time
+}
+
+// FieldChange holds the value of a field before and after a change. Old is
+// null for created tasks.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+type AuditFilter struct {
+	OwnerId uuid.UUID
+	TaskId  *uuid.UUID
+	Limit   int
+	Cursor  string
+}
+
+type AuditPage struct {
+	Events     []AuditEvent
+	NextCursor string
+}
+
+// DiffTasks returns the fields that differ between two states of a task.
+// A nil old means the task was created.
+func DiffTasks(old *Task, new Task) map[string]FieldChange {
+	after := auditFields(new)
+	changes := make(map[string]FieldChange)
+
+	if old == nil {
+		for name, value := range after {
+			changes[name] = FieldChange{New: value}
+		}
+		return changes
+	}
+
+	before := auditFields(*old)
+	for name, value := range after {
+		if !equalJSON(before[name], value) {
+			changes[name] = FieldChange{Old: before[name], New: value}
+		}
+	}
+
+	return changes
+}
+
+// auditFields lists the fields a user can change, as they are stored in the
+// audit log.
+func auditFields(t Task) map[string]any {
+	fields := map[string]any{
+		"title":       t.Title,
+		"description": t.Description,
+		"task_status": t.TaskStatus,
+		"priority":    t.Priority,
+		"repeat_task": t.RepeatTask,
+		"timezone":    t.Timezone,
+		"start_at":    t.StartAt,
+		"due_at":      t.DueAt,
+		"project_id":  t.ProjectId,
+		"parent_id":   t.ParentId,
+		"tags":        t.Tags,
+		"deleted_at":  t.DeletedAt,
+	}
+	if len(t.Tags) == 0 {
+		fields["tags"] = []string{}
+	}
+	return fields
+}
+
+func equalJSON(a, b any) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return string(x) == string(y)
+}
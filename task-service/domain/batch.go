@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type BatchAction string
+
+const (
+	BatchCreate BatchAction = "create"
+	BatchUpdate BatchAction = "update"
+	BatchDelete BatchAction = "delete"
+)
+
+// BatchOp is one operation of a batch. Task is the new task for create and
+// the changes for update; Cascade only applies to delete.
+type BatchOp struct {
+	Action  BatchAction
+	Id      uuid.UUID
+	Task    Task
+	Cascade bool
+}
+
+// BatchResult is the outcome of one applied operation: the task as stored
+// after create and update, the ids of the removed tasks after delete.
+type BatchResult struct {
+	Task    Task
+	Deleted []uuid.UUID
+}
+
+// BatchError tells which operation made the batch fail.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
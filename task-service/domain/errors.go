@@ -0,0 +1,24 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrStartAfterDue        = errors.New("start date is after due date")
+	ErrTransitionNotAllowed = errors.New("status transition is not allowed")
+	ErrTaskBlocked          = errors.New("task is blocked by unfinished tasks")
+	ErrTaskNotDone          = errors.New("task is not done")
+	ErrTaskNotFound         = errors.New("task not found")
+	ErrUserExists           = errors.New("user already exists")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrProjectNotFound      = errors.New("project not found")
+	ErrProjectExists        = errors.New("project already exists")
+	ErrParentNotFound       = errors.New("parent task not found")
+	ErrTaskCycle            = errors.New("task cannot be a subtask of itself")
+	ErrBlockerNotFound      = errors.New("blocking task not found")
+	ErrDependencyCycle      = errors.New("dependency would create a cycle")
+	ErrDependencyNotFound   = errors.New("dependency not found")
+	ErrVersionMismatch      = errors.New("task version does not match")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+)
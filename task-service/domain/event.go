@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	TaskCreated EventType = "TaskCreated"
+	TaskUpdated EventType = "TaskUpdated"
+	TaskDeleted EventType = "TaskDeleted"
+)
+
+// Event is a domain event queued in the outbox. Payload is the JSON encoded
+// TaskEvent.
+type Event struct {
+	Id        int64
+	Type      EventType
+	TaskId    uuid.UUID
+	OwnerId   uuid.UUID
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// TaskEvent is what other services receive: the task as it is after the
+// change and the fields that changed.
+type TaskEvent struct {
+	Id          uuid.UUID              `json:"id"`
+	OwnerId     uuid.UUID              `json:"owner_id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	TaskStatus  TaskStatus             `json:"task_status"`
+	Priority    TaskPriority           `json:"priority"`
+	RepeatTask  TaskRepeatType         `json:"repeat_task"`
+	ProjectId   *uuid.UUID             `json:"project_id"`
+	ParentId    *uuid.UUID             `json:"parent_id"`
+	Tags        []string               `json:"tags"`
+	StartAt     *time.Time             `json:"start_at"`
+	DueAt       *time.Time             `json:"due_at"`
+	Timezone    string                 `json:"timezone,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	DeletedAt   *time.Time             `json:"deleted_at"`
+	Version     int64                  `json:"version"`
+	RequestId   string                 `json:"request_id,omitempty"`
+	Changes     map[string]FieldChange `json:"changes"`
+}
+
+// EventTypeFor returns the event published for an audited change. A
+// restored task is announced as updated: its deleted_at is cleared.
+func EventTypeFor(action AuditAction) EventType {
+	switch action {
+	case AuditCreate:
+		return TaskCreated
+	case AuditDelete:
+		return TaskDeleted
+	default:
+		return TaskUpdated
+	}
+}
+
+func NewTaskEvent(task Task, changes map[string]FieldChange, requestId string) TaskEvent {
+	return TaskEvent{
+		Id:          task.Id,
+		OwnerId:     task.OwnerId,
+		Title:       task.Title,
+		Description: task.Description,
+		TaskStatus:  task.TaskStatus,
+		Priority:    task.Priority,
+		RepeatTask:  task.RepeatTask,
+		ProjectId:   task.ProjectId,
+		ParentId:    task.ParentId,
+		Tags:        task.Tags,
+		StartAt:     task.StartAt,
+		DueAt:       task.DueAt,
+		Timezone:    task.Timezone,
+		CreatedAt:   task.CreatedAt,
+		DeletedAt:   task.DeletedAt,
+		Version:     task.Version,
+		RequestId:   requestId,
+		Changes:     changes,
+	}
+}
+
+// Matches returns whether the change concerns a board showing the project
+// and statuses; empty values match everything. A task matches if it matches
+// after the change or did before it, so the board can remove tasks that left.
+func (e TaskEvent) Matches(projectId string, statuses []string) bool {
+	if projectId != "" {
+		now := e.ProjectId != nil && e.ProjectId.String() == projectId
+		if !now && !e.changedFrom("project_id", projectId) {
+			return false
+		}
+	}
+
+	if len(statuses) > 0 {
+		now := slices.Contains(statuses, string(e.TaskStatus))
+		if !now && !slices.ContainsFunc(statuses, func(status string) bool {
+			return e.changedFrom("task_status", status)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e TaskEvent) changedFrom(field, value string) bool {
+	change, ok := e.Changes[field]
+	if !ok {
+		return false
+	}
+	old, ok := change.Old.(string)
+	return ok && old == value
+}
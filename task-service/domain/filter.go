@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SortOrder string
+
+type TagMode string
+
+const (
+	// TagsAll matches tasks that have every requested tag.
+	TagsAll TagMode = "ALL"
+	// TagsAny matches tasks that have at least one of the requested tags.
+	TagsAny TagMode = "ANY"
+)
+
+const (
+	ASC  SortOrder = "ASC"
+	DESC SortOrder = "DESC"
+)
+
+type TaskFilter struct {
+	OwnerId       uuid.UUID
+	ProjectId     *uuid.UUID
+	Statuses      []TaskStatus
+	Priorities    []TaskPriority
+	RepeatTypes   []TaskRepeatType
+	Tags          []string
+	TagMode       TagMode
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Title         string
+	SortBy        string
+	Order         SortOrder
+	Limit         int
+	Cursor        string
+}
+
+type TaskPage struct {
+	Tasks      []Task
+	NextCursor string
+}
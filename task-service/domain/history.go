@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StatusChange struct {
+	TaskId    uuid.UUID
+	From      TaskStatus
+	To        TaskStatus
+	Actor     string
+	ChangedAt time.Time
+}
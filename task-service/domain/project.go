@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Project struct {
+	Id          uuid.UUID
+	Name        string
+	Description string
+	OwnerId     uuid.UUID
+	CreatedAt   time.Time
+	TaskCount   int
+}
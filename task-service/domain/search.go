@@ -0,0 +1,8 @@
+package domain
+
+type SearchResult struct {
+	Task                 Task
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
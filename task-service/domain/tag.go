@@ -0,0 +1,30 @@
+package domain
+
+import "strings"
+
+type TagUsage struct {
+	Name      string
+	TaskCount int
+}
+
+// NormalizeTags lower-cases and trims tag names and drops empty names and
+// duplicates, keeping the first occurrence order. nil stays nil so callers
+// can tell "no change" from "no tags".
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
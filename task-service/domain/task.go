@@ -1,34 +1,81 @@
-package domain
-
-import (
-	"time"
-
-	"github.com/google/uuid"
-)
-
-type TaskStatus string
-
-const (
-	TODO        TaskStatus = "TODO"
-	DONE        TaskStatus = "DONE"
-	IN_PROGRESS TaskStatus = "IN_PROGRESS"
-)
-
-type TaskRepeatType string
-
-const (
-	DAILY   TaskRepeatType = "DAILY"
-	WEEKLY  TaskRepeatType = "WEEKLY"
-	MONTHLY TaskRepeatType = "MONTHLY"
-	YEARLY  TaskRepeatType = "YEARLY"
-	NEVER   TaskRepeatType = "NEVER"
-)
-
-type Task struct {
-	Id          uuid.UUID
-	Title       string
-	Description string
-	TaskStatus  TaskStatus
-	CreatedAt   time.Time
-	RepeatTask  TaskRepeatType
-}
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskStatus string
+
+const (
+	TODO        TaskStatus = "TODO"
+	DONE        TaskStatus = "DONE"
+	IN_PROGRESS TaskStatus = "IN_PROGRESS"
+)
+
+type TaskPriority string
+
+const (
+	URGENT TaskPriority = "URGENT"
+	HIGH   TaskPriority = "HIGH"
+	MEDIUM TaskPriority = "MEDIUM"
+	LOW    TaskPriority = "LOW"
+)
+
+type TaskRepeatType string
+
+const (
+	DAILY   TaskRepeatType = "DAILY"
+	WEEKLY  TaskRepeatType = "WEEKLY"
+	MONTHLY TaskRepeatType = "MONTHLY"
+	YEARLY  TaskRepeatType = "YEARLY"
+	NEVER   TaskRepeatType = "NEVER"
+)
+
+// Task is a task of a user. Timezone is the IANA name of the time zone its
+// series repeats in, empty for the recurrence.timezone setting.
+type Task struct {
+	Id          uuid.UUID
+	Title       string
+	Description string
+	TaskStatus  TaskStatus
+	Priority    TaskPriority
+	CreatedAt   time.Time
+	RepeatTask  TaskRepeatType
+	SeriesId    uuid.UUID
+	SeriesStart time.Time
+	Occurrence  int
+	Timezone    string
+	StartAt     *time.Time
+	DueAt       *time.Time
+	OwnerId     uuid.UUID
+	ProjectId   *uuid.UUID
+	ParentId    *uuid.UUID
+	Tags        []string
+	Version     int64
+	DeletedAt   *time.Time
+
+	// ClearStartAt and ClearDueAt make an update remove the dates.
+	ClearStartAt bool `json:"-"`
+	ClearDueAt   bool `json:"-"`
+}
+
+// IsOverdue reports whether the task is past its due date and still not done.
+func (t Task) IsOverdue(now time.Time) bool {
+	return t.DueAt != nil && t.TaskStatus != DONE && now.After(*t.DueAt)
+}
+
+// Progress counts the subtasks of a task at every depth.
+type Progress struct {
+	Total int
+	Done  int
+}
+
+// Percent is the share of done subtasks rounded down, 0 without subtasks.
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
@@ -0,0 +1,36 @@
+package domain
+
+import "fmt"
+
+// StatusCheck decides whether a task may move from one status to another.
+// It is run on the locked task in the transaction of the change.
+type StatusCheck func(from, to TaskStatus) error
+
+// TransitionError is returned when the workflow does not allow a status
+// change.
+type TransitionError struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("%v: %s -> %s", ErrTransitionNotAllowed, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrTransitionNotAllowed
+}
+
+// BlockedError lists the unfinished tasks that keep a task from moving to
+// IN_PROGRESS or DONE.
+type BlockedError struct {
+	Blockers []Task
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%v: %d blockers", ErrTaskBlocked, len(e.Blockers))
+}
+
+func (e *BlockedError) Unwrap() error {
+	return ErrTaskBlocked
+}
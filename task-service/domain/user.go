@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type User struct {
+	Id           uuid.UUID
+	Email        string
+	PasswordHash string
+	CreatedAt    time.Time
+}
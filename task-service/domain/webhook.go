@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead is a delivery that failed every attempt. It stays in the
+	// delivery log until it is retried by hand.
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+// Webhook is a subscription of a user to the events of their tasks. An empty
+// Events list subscribes to all events.
+type Webhook struct {
+	Id        uuid.UUID
+	OwnerId   uuid.UUID
+	URL       string
+	Events    []EventType
+	Secret    string
+	Active    bool
+	CreatedAt time.Time
+}
+
+// WebhookUpdate holds the fields to change, nil fields are kept.
+type WebhookUpdate struct {
+	URL    *string
+	Events *[]EventType
+	Secret *string
+	Active *bool
+}
+
+type WebhookDelivery struct {
+	Id             int64
+	WebhookId      uuid.UUID
+	EventId        int64
+	EventType      EventType
+	Payload        []byte
+	Status         DeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+	// URL and Secret of the webhook, set on claimed deliveries.
+	URL    string
+	Secret string
+}
+
+type DeliveryFilter struct {
+	OwnerId   uuid.UUID
+	WebhookId uuid.UUID
+	Status    DeliveryStatus
+	Limit     int
+	Cursor    string
+}
+
+type DeliveryPage struct {
+	Deliveries []WebhookDelivery
+	NextCursor string
+}
+
+// DeliveryAttempt is the outcome of one attempt to deliver an event.
+type DeliveryAttempt struct {
+	Status     DeliveryStatus
+	StatusCode int
+	Error      string
+	// NextAttemptAt is when a pending delivery is tried again.
+	NextAttemptAt time.Time
+}
@@ -1,59 +1,161 @@
-package config
-
-import (
-	"log"
-	"os"
-	"time"
-
-	"github.com/ilyakaznacheev/cleanenv"
-)
-
-type Config struct {
-	Environment string     `yaml:"environment" env-default:"local"`
-	HTTPServer  HTTPServer `yaml:"http_server"`
-	Database    Database   `yaml:"database"`
-	Redis       Redis      `yaml:"redis"`
-}
-
-type HTTPServer struct {
-	Address      string        `yaml:"address" env-default:"localhost:8080"`
-	Timeout      time.Duration `yaml:"timeout" env-default:"5"`
-	IddleTimeout time.Duration `yaml:"idle_timeout" env-default:"60"`
-}
-
-type Database struct {
-	Host     string `yaml:"host" env-default:"localhost"`
-	Port     int    `yaml:"port" env-default:"5432"`
-	User     string `yaml:"user" env-default:"postgres"`
-	Password string `yaml:"password" env-default:"postgres"`
-	Name     string `yaml:"name" env-default:"task_manager"`
-	SSLMode  string `yaml:"sslmode" env-default:"disable"`
-}
-
-type Redis struct {
-	Host        string        `yaml:"host" env-default:"localhost"`
-	Port        int           `yaml:"port" env-default:"6379"`
-	User        string        `yaml:"user" env-default:"user"`
-	Password    string        `yaml:"password" env-default:""`
-	DB          int           `yaml:"db" env-default:"0"`
-	MaxRetries  int           `yaml:"max_retries" env-default:"4"`
-	DialTimeout time.Duration `yaml:"dial_timeout" env-default:"5s"`
-}
-
-func MustLoad() *Config {
-	configPath := os.Getenv("CONFIG_PATH")
-	if configPath == "" {
-		log.Fatal("CONFIG_PATH environment variable is not set")
-	}
-
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		log.Fatalf("Config file does not exist at path: %s", configPath)
-	}
-
-	var cfg Config
-
-	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
-		log.Fatalf("Can't read config file")
-	}
-	return &cfg
-}
+package config
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+type Config struct {
+	Environment string      `yaml:"environment" env-default:"local"`
+	HTTPServer  HTTPServer  `yaml:"http_server"`
+	GRPCServer  GRPCServer  `yaml:"grpc_server"`
+	AdminServer AdminServer `yaml:"admin_server"`
+	Database    Database    `yaml:"database"`
+	Redis       Redis       `yaml:"redis"`
+	Recurrence  Recurrence  `yaml:"recurrence"`
+	Workflow    Workflow    `yaml:"workflow"`
+	Auth        Auth        `yaml:"auth"`
+	Trash       Trash       `yaml:"trash"`
+	Outbox      Outbox      `yaml:"outbox"`
+	Webhook     Webhook     `yaml:"webhook"`
+}
+
+type HTTPServer struct {
+	Address      string        `yaml:"address" env-default:"localhost:8080"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"5"`
+	IddleTimeout time.Duration `yaml:"idle_timeout" env-default:"60"`
+	// ShutdownTimeout is how long a shutdown waits for in-flight requests
+	// and background workers before closing the connections anyway.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"10s"`
+}
+
+// GRPCServer configures the gRPC API served next to the HTTP one.
+type GRPCServer struct {
+	Address string `yaml:"address" env-default:"localhost:9090"`
+}
+
+// AdminServer serves the runtime counters of /debug/vars apart from the
+// API, on an address that is not public.
+type AdminServer struct {
+	Address string `yaml:"address" env-default:"localhost:6060"`
+}
+
+type Database struct {
+	Host     string  `yaml:"host" env-default:"localhost"`
+	Port     int     `yaml:"port" env-default:"5432"`
+	User     string  `yaml:"user" env-default:"postgres"`
+	Password string  `yaml:"password" env-default:"postgres"`
+	Name     string  `yaml:"name" env-default:"task_manager"`
+	SSLMode  string  `yaml:"sslmode" env-default:"disable"`
+	Breaker  Breaker `yaml:"breaker"`
+}
+
+type Redis struct {
+	Host        string        `yaml:"host" env-default:"localhost"`
+	Port        int           `yaml:"port" env-default:"6379"`
+	User        string        `yaml:"user" env-default:"user"`
+	Password    string        `yaml:"password" env-default:""`
+	DB          int           `yaml:"db" env-default:"0"`
+	MaxRetries  int           `yaml:"max_retries" env-default:"4"`
+	DialTimeout time.Duration `yaml:"dial_timeout" env-default:"5s"`
+	// CacheTTL is how long a cached task is fresh, plus up to CacheJitter.
+	// Stale tasks are served for CacheStaleTTL more while they are read
+	// again, 0 disables that. Missing tasks are remembered for
+	// CacheMissingTTL.
+	CacheTTL        time.Duration `yaml:"cache_ttl" env-default:"5m"`
+	CacheJitter     time.Duration `yaml:"cache_jitter" env-default:"30s"`
+	CacheStaleTTL   time.Duration `yaml:"cache_stale_ttl" env-default:"0s"`
+	CacheMissingTTL time.Duration `yaml:"cache_missing_ttl" env-default:"30s"`
+	// Every replica also keeps up to CacheLocalSize tasks in memory for
+	// CacheLocalTTL, 0 disables that. Changes are announced on CacheChannel.
+	CacheLocalSize int           `yaml:"cache_local_size" env-default:"10000"`
+	CacheLocalTTL  time.Duration `yaml:"cache_local_ttl" env-default:"10s"`
+	CacheChannel   string        `yaml:"cache_channel" env-default:"task-cache-invalidations"`
+	// HealthInterval is how often Redis is pinged, so the client reconnects
+	// after an outage even when no request uses the cache.
+	HealthInterval time.Duration `yaml:"health_interval" env-default:"5s"`
+	Breaker        Breaker       `yaml:"breaker"`
+}
+
+// Breaker stops calls to a backend after Threshold failures in a row and
+// tries it again after Timeout. A Threshold of 0 never stops calls.
+type Breaker struct {
+	Threshold int           `yaml:"threshold" env-default:"5"`
+	Timeout   time.Duration `yaml:"timeout" env-default:"10s"`
+}
+
+type Recurrence struct {
+	Interval  time.Duration `yaml:"interval" env-default:"1m"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+	Timezone  string        `yaml:"timezone" env-default:"UTC"`
+}
+
+// Workflow maps a task status to the statuses it may be changed to.
+// An empty map means workflow.DefaultTransitions.
+type Workflow struct {
+	Transitions map[string][]string `yaml:"transitions"`
+}
+
+// Auth configures the tokens. The signing key is only read from the
+// environment, so it never ends up in a config file.
+type Auth struct {
+	Secret     string        `yaml:"-" env:"AUTH_SECRET" env-required:"true"`
+	AccessTTL  time.Duration `yaml:"access_ttl" env-default:"15m"`
+	RefreshTTL time.Duration `yaml:"refresh_ttl" env-default:"720h"`
+}
+
+// Trash configures how long deleted tasks can be restored before the purge
+// worker removes them for good.
+type Trash struct {
+	Retention     time.Duration `yaml:"retention" env-default:"720h"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+	BatchSize     int           `yaml:"batch_size" env-default:"500"`
+}
+
+// Outbox configures the relay publishing queued domain events to the Redis
+// stream and to the Pub/Sub channel live streams of all replicas listen to.
+type Outbox struct {
+	Stream    string        `yaml:"stream" env-default:"task-events"`
+	Channel   string        `yaml:"channel" env-default:"task-events"`
+	MaxLen    int64         `yaml:"max_len" env-default:"100000"`
+	Interval  time.Duration `yaml:"interval" env-default:"1s"`
+	BatchSize int           `yaml:"batch_size" env-default:"100"`
+	Retention time.Duration `yaml:"retention" env-default:"168h"`
+	// GapTimeout is how long the relay holds events back behind a missing
+	// id, waiting for the transaction that took the id to commit.
+	GapTimeout time.Duration `yaml:"gap_timeout" env-default:"10s"`
+}
+
+// Webhook configures the worker delivering events to webhook subscribers. A
+// delivery is marked dead after MaxAttempts failed attempts; the wait between
+// attempts starts at BackoffBase and doubles up to BackoffMax.
+type Webhook struct {
+	Interval    time.Duration `yaml:"interval" env-default:"5s"`
+	BatchSize   int           `yaml:"batch_size" env-default:"50"`
+	Concurrency int           `yaml:"concurrency" env-default:"8"`
+	Timeout     time.Duration `yaml:"timeout" env-default:"10s"`
+	MaxAttempts int           `yaml:"max_attempts" env-default:"8"`
+	BackoffBase time.Duration `yaml:"backoff_base" env-default:"30s"`
+	BackoffMax  time.Duration `yaml:"backoff_max" env-default:"6h"`
+}
+
+func MustLoad() *Config {
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		log.Fatal("CONFIG_PATH environment variable is not set")
+	}
+
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		log.Fatalf("Config file does not exist at path: %s", configPath)
+	}
+
+	var cfg Config
+
+	if err := cleanenv.ReadConfig(configPath, &cfg); err != nil {
+		log.Fatalf("Can't read config: %s", err)
+	}
+	return &cfg
+}
@@ -0,0 +1,59 @@
+package task
+
+import (
+	"task-service/domain"
+	"task-service/internal/grpc/taskpb"
+	"task-service/internal/lib/api/request"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toProto(task domain.Task, now time.Time) *taskpb.Task {
+	return &taskpb.Task{
+		Id:          task.Id.String(),
+		Title:       task.Title,
+		Description: task.Description,
+		TaskStatus:  string(task.TaskStatus),
+		Priority:    string(task.Priority),
+		RepeatTask:  string(task.RepeatTask),
+		CreatedAt:   timestamppb.New(task.CreatedAt),
+		StartAt:     toTimestamp(task.StartAt),
+		DueAt:       toTimestamp(task.DueAt),
+		ProjectId:   formatId(task.ProjectId),
+		ParentId:    formatId(task.ParentId),
+		Tags:        task.Tags,
+		Version:     task.Version,
+		Overdue:     task.IsOverdue(now),
+		Timezone:    task.Timezone,
+	}
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// updatedTime is a date of UpdateTaskRequest: set to ts, removed with clear
+// or kept when neither is given.
+func updatedTime(ts *timestamppb.Timestamp, clear bool) request.Time {
+	return request.Time{Set: ts != nil || clear, Value: fromTimestamp(ts)}
+}
+
+func formatId(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,342 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/grpc/taskpb"
+	"task-service/internal/http/handlers/task/change"
+	"task-service/internal/http/handlers/task/delete"
+	"task-service/internal/http/handlers/task/events"
+	"task-service/internal/http/handlers/task/get"
+	"task-service/internal/http/handlers/task/list"
+	"task-service/internal/http/handlers/task/save"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/workflow"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TaskRepo is what the HTTP task handlers need from the repository, so both
+// APIs read and write tasks the same way.
+type TaskRepo interface {
+	save.TaskSaver
+	get.TaskGetter
+	change.TaskChanger
+	delete.TaskDeleter
+	list.TaskLister
+	events.EventLister
+}
+
+// Service implements taskpb.TaskServiceServer on top of the request types,
+// validation rules and repository interfaces of the HTTP handlers.
+type Service struct {
+	taskpb.UnimplementedTaskServiceServer
+
+	log        *slog.Logger
+	repo       TaskRepo
+	wf         *workflow.Workflow
+	subscriber events.Subscriber
+}
+
+func New(log *slog.Logger, repo TaskRepo, wf *workflow.Workflow, subscriber events.Subscriber) *Service {
+	return &Service{
+		log:        log,
+		repo:       repo,
+		wf:         wf,
+		subscriber: subscriber,
+	}
+}
+
+func (s *Service) CreateTask(ctx context.Context, in *taskpb.CreateTaskRequest) (*taskpb.Task, error) {
+	const op = "grpc.task.CreateTask"
+
+	log := s.log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(ctx)))
+
+	req := save.Request{
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		RepeatTask:  in.GetRepeatTask(),
+		Priority:    in.GetPriority(),
+		ProjectId:   in.GetProjectId(),
+		ParentId:    in.GetParentId(),
+		Tags:        in.GetTags(),
+		StartAt:     fromTimestamp(in.GetStartAt()),
+		DueAt:       fromTimestamp(in.GetDueAt()),
+		Timezone:    in.GetTimezone(),
+	}
+
+	if err := newValidator().Struct(req); err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	task, err := save.CreateTask(req, auth.UserId(ctx))
+	if err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	err = s.repo.SaveTask(ctx, task)
+	if errors.Is(err, domain.ErrProjectNotFound) {
+		log.Info("Project not found", slog.String("ProjectId", req.ProjectId))
+		return nil, status.Error(codes.NotFound, "Project not found")
+	}
+	if errors.Is(err, domain.ErrParentNotFound) {
+		log.Info("Parent task not found", slog.String("ParentId", req.ParentId))
+		return nil, status.Error(codes.NotFound, "Parent task not found")
+	}
+	if err != nil {
+		log.Error("Failed to save task", sl.Error(err))
+		return nil, status.Error(codes.Internal, "Failed to save task")
+	}
+
+	log.Info("Task created successfully", slog.String("TaskId", task.Id.String()))
+
+	return toProto(task, time.Now()), nil
+}
+
+func (s *Service) GetTask(ctx context.Context, in *taskpb.GetTaskRequest) (*taskpb.Task, error) {
+	const op = "grpc.task.GetTask"
+
+	log := s.log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(ctx)))
+
+	req := get.Request{
+		Id: in.GetId(),
+	}
+
+	if err := newValidator().Struct(req); err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	task, err := s.repo.GetTaskById(auth.UserId(ctx), uuid.MustParse(req.Id))
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		log.Info("Task not found", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.NotFound, "Task not found")
+	}
+	if err != nil {
+		log.Error("Failed to get task", sl.Error(err))
+		return nil, status.Error(codes.Internal, "Failed to get task")
+	}
+
+	log.Info("Task get", slog.String("TaskId", task.Id.String()))
+
+	return toProto(task, time.Now()), nil
+}
+
+func (s *Service) UpdateTask(ctx context.Context, in *taskpb.UpdateTaskRequest) (*taskpb.Task, error) {
+	const op = "grpc.task.UpdateTask"
+
+	log := s.log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(ctx)))
+
+	req := change.Request{
+		Id:          in.GetId(),
+		Title:       in.GetTitle(),
+		Description: in.GetDescription(),
+		TaskStatus:  in.GetTaskStatus(),
+		RepeatTask:  in.GetRepeatTask(),
+		Priority:    in.GetPriority(),
+		StartAt:     updatedTime(in.GetStartAt(), in.GetClearStartAt()),
+		DueAt:       updatedTime(in.GetDueAt(), in.GetClearDueAt()),
+		Timezone:    in.GetTimezone(),
+	}
+	if in.GetTags() != nil {
+		req.Tags = append([]string{}, in.GetTags().GetValues()...)
+	}
+
+	if err := newValidator().Struct(req); err != nil || in.GetVersion() < 0 {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+	if (in.GetClearStartAt() && in.GetStartAt() != nil) || (in.GetClearDueAt() && in.GetDueAt() != nil) {
+		log.Error("Date is both set and cleared", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	updates, err := change.CreateUpdates(req)
+	if err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+	updates.Version = in.GetVersion()
+
+	userId := auth.UserId(ctx)
+	id := uuid.MustParse(req.Id)
+
+	err = s.repo.UpdateTaskById(ctx, userId, id, updates, s.wf.Check)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		log.Info("Task not found", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.NotFound, "Task not found")
+	}
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		log.Info("Precondition failed", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.Aborted, "Task was changed")
+	}
+	if errors.Is(err, domain.ErrStartAfterDue) {
+		log.Info("Start date is after due date", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.InvalidArgument, "Start date is after due date")
+	}
+	var transitionErr *domain.TransitionError
+	if errors.As(err, &transitionErr) {
+		log.Error("Status transition rejected", sl.Error(err))
+		return nil, status.Error(codes.FailedPrecondition, transitionErr.Error())
+	}
+	var blockedErr *domain.BlockedError
+	if errors.As(err, &blockedErr) {
+		log.Info("Task is blocked", slog.String("TaskId", req.Id), slog.Int("blockers", len(blockedErr.Blockers)))
+		return nil, blockedError(blockedErr.Blockers)
+	}
+	if err != nil {
+		log.Error("Failed to update task", sl.Error(err))
+		return nil, status.Error(codes.Internal, "Failed to update task")
+	}
+
+	updated, err := s.repo.GetTaskById(userId, id)
+	if err != nil {
+		log.Error("Failed to get updated task", sl.Error(err))
+		return nil, status.Error(codes.Internal, "Failed to get updated task")
+	}
+
+	log.Info("Task updated successfully", slog.String("TaskId", req.Id))
+
+	return toProto(updated, time.Now()), nil
+}
+
+func (s *Service) DeleteTask(ctx context.Context, in *taskpb.DeleteTaskRequest) (*taskpb.DeleteTaskResponse, error) {
+	const op = "grpc.task.DeleteTask"
+
+	log := s.log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(ctx)))
+
+	req := delete.Request{
+		Id: in.GetId(),
+	}
+	if in.GetKeepSubtasks() {
+		req.Subtasks = "keep"
+	}
+
+	if err := newValidator().Struct(req); err != nil || in.GetVersion() < 0 {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	deleted, err := s.repo.DeleteTaskById(ctx, auth.UserId(ctx), uuid.MustParse(req.Id), req.Subtasks != "keep", in.GetVersion())
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		log.Info("Task not found", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.NotFound, "Task not found")
+	}
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		log.Info("Precondition failed", slog.String("TaskId", req.Id))
+		return nil, status.Error(codes.Aborted, "Task was changed")
+	}
+	if err != nil {
+		log.Error("Failed to delete task", sl.Error(err))
+		return nil, status.Error(codes.Internal, "Failed to delete task")
+	}
+
+	ids := make([]string, len(deleted))
+	for i, id := range deleted {
+		ids[i] = id.String()
+	}
+
+	log.Info("Task deleted", slog.String("TaskId", req.Id), slog.Int("deleted", len(deleted)))
+
+	return &taskpb.DeleteTaskResponse{DeletedIds: ids}, nil
+}
+
+func (s *Service) ListTasks(ctx context.Context, in *taskpb.ListTasksRequest) (*taskpb.ListTasksResponse, error) {
+	const op = "grpc.task.ListTasks"
+
+	log := s.log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(ctx)))
+
+	req := list.Request{
+		ProjectId:  in.GetProjectId(),
+		TaskStatus: in.GetTaskStatus(),
+		Priority:   in.GetPriority(),
+		RepeatTask: in.GetRepeatTask(),
+		Tags:       in.GetTags(),
+		TagMode:    strings.ToLower(in.GetTagMode()),
+		Title:      in.GetTitle(),
+		Sort:       in.GetSort(),
+		Order:      strings.ToLower(in.GetOrder()),
+		Limit:      int(in.GetLimit()),
+		Cursor:     in.GetCursor(),
+	}
+
+	if err := newValidator().Struct(req); err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	filter, err := list.CreateFilter(req, "", "")
+	if err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	filter.CreatedAfter = fromTimestamp(in.GetCreatedFrom())
+	filter.CreatedBefore = fromTimestamp(in.GetCreatedTo())
+	filter.OwnerId = auth.UserId(ctx)
+
+	page, err := s.repo.ListTasks(filter)
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		log.Error("Invalid cursor", sl.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "Invalid cursor")
+	}
+	if err != nil {
+		log.Error("Failed to list tasks", sl.Error(err))
+		return nil, status.Error(codes.Internal, "Failed to list tasks")
+	}
+
+	now := time.Now()
+	tasks := make([]*taskpb.Task, 0, len(page.Tasks))
+	for _, task := range page.Tasks {
+		tasks = append(tasks, toProto(task, now))
+	}
+
+	log.Info("Tasks listed", slog.Int("count", len(tasks)))
+
+	return &taskpb.ListTasksResponse{
+		Tasks:      tasks,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// blockedError lists the unfinished blockers as precondition violations,
+// like the blockers of the HTTP 409 response.
+func blockedError(blockers []domain.Task) error {
+	details := &errdetails.PreconditionFailure{}
+	for _, task := range blockers {
+		details.Violations = append(details.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        "BLOCKED",
+			Subject:     task.Id.String(),
+			Description: task.Title + " is " + string(task.TaskStatus),
+		})
+	}
+
+	st, err := status.New(codes.FailedPrecondition, "Task is blocked by unfinished tasks").WithDetails(details)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, "Task is blocked by unfinished tasks")
+	}
+	return st.Err()
+}
+
+func newValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterValidation("id_valid", validators.IsValidId)
+	validate.RegisterValidation("task_status_valid", validators.IsValidTaskStatus)
+	validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
+	validate.RegisterValidation("timezone_valid", validators.IsValidTimezone)
+	validate.RegisterValidation("priority_valid", validators.IsValidPriority)
+	return validate
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"slices"
+	"task-service/domain"
+	"task-service/internal/grpc/taskpb"
+	"task-service/internal/http/handlers/task/events"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/hub"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// buffer is how many live events a stream may fall behind before it is
+// closed; the client then resumes with last_event_id.
+const buffer = 256
+
+// WatchTasks streams the changes of the caller's tasks like GET /task/events:
+// missed events after last_event_id first, then live ones from the hub.
+func (s *Service) WatchTasks(in *taskpb.WatchTasksRequest, stream grpc.ServerStreamingServer[taskpb.TaskEvent]) error {
+	const op = "grpc.task.WatchTasks"
+
+	ctx := stream.Context()
+	log := s.log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(ctx)))
+
+	req := events.Request{
+		ProjectId:   in.GetProjectId(),
+		TaskStatus:  in.GetTaskStatus(),
+		LastEventId: in.GetLastEventId(),
+	}
+
+	if err := newValidator().Struct(req); err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		return status.Error(codes.InvalidArgument, "Invalid request")
+	}
+
+	ownerId := auth.UserId(ctx)
+
+	// Subscribe before reading missed events, so nothing published in
+	// between is lost.
+	client := s.subscriber.Subscribe(ownerId, buffer)
+	defer s.subscriber.Unsubscribe(client)
+
+	log.Info("Event stream opened", slog.Int64("last_event_id", req.LastEventId))
+
+	send := func(event domain.Event) error {
+		var task domain.TaskEvent
+		if err := json.Unmarshal(event.Payload, &task); err != nil {
+			log.Error("Failed to decode event", slog.Int64("event_id", event.Id), sl.Error(err))
+			return nil
+		}
+		if !task.Matches(req.ProjectId, req.TaskStatus) {
+			return nil
+		}
+
+		return stream.Send(toProtoEvent(event, task))
+	}
+
+	resume, err := hub.Replay(s.repo, ownerId, req.LastEventId, send)
+	if errors.Is(err, hub.ErrReplay) {
+		log.Error("Failed to list missed events", sl.Error(err))
+		return status.Error(codes.Internal, "Failed to stream events")
+	}
+	if err != nil {
+		log.Info("Event stream closed", sl.Error(err))
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Event stream closed")
+			return nil
+		case <-client.Done():
+			log.Info("Event stream dropped, closing", sl.Error(client.Err()))
+			if errors.Is(client.Err(), hub.ErrClosed) {
+				return status.Error(codes.Unavailable, "Server is shutting down, resume with last_event_id")
+			}
+			return status.Error(codes.ResourceExhausted, "Event stream fell behind, resume with last_event_id")
+		case event := <-client.Events():
+			if !resume.Live(event) {
+				continue
+			}
+			if err := send(event); err != nil {
+				log.Info("Event stream closed", sl.Error(err))
+				return err
+			}
+		}
+	}
+}
+
+func toProtoEvent(event domain.Event, task domain.TaskEvent) *taskpb.TaskEvent {
+	changed := make([]string, 0, len(task.Changes))
+	for field := range task.Changes {
+		changed = append(changed, field)
+	}
+	slices.Sort(changed)
+
+	return &taskpb.TaskEvent{
+		Id:   event.Id,
+		Type: string(event.Type),
+		Task: toProto(domain.Task{
+			Id:          task.Id,
+			Title:       task.Title,
+			Description: task.Description,
+			TaskStatus:  task.TaskStatus,
+			Priority:    task.Priority,
+			RepeatTask:  task.RepeatTask,
+			ProjectId:   task.ProjectId,
+			ParentId:    task.ParentId,
+			Tags:        task.Tags,
+			StartAt:     task.StartAt,
+			DueAt:       task.DueAt,
+			CreatedAt:   task.CreatedAt,
+			DeletedAt:   task.DeletedAt,
+			Version:     task.Version,
+		}, time.Now()),
+		ChangedFields: changed,
+		CreatedAt:     timestamppb.New(event.CreatedAt),
+	}
+}
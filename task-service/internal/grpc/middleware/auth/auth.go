@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/token"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Interceptor rejects calls without a valid access token in the
+// "authorization: Bearer <token>" metadata and stores the caller's user id
+// in the context, where auth.UserId finds it like for HTTP requests.
+type Interceptor struct {
+	log    *slog.Logger
+	parser auth.TokenParser
+	public []string
+}
+
+// New returns an interceptor letting calls of the public services, like
+// "/grpc.health.v1.Health/", through without a token.
+func New(log *slog.Logger, parser auth.TokenParser, public ...string) *Interceptor {
+	log = log.With(
+		slog.String("component", "grpc/middleware/auth"),
+	)
+
+	log.Info("grpc auth interceptor enabled")
+
+	return &Interceptor{
+		log:    log,
+		parser: parser,
+		public: public,
+	}
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	for _, prefix := range i.public {
+		if strings.HasPrefix(method, prefix) {
+			return ctx, nil
+		}
+	}
+
+	raw, ok := bearerToken(ctx)
+	if !ok {
+		i.log.Info("Missing bearer token", slog.String("method", method), slog.String("request_id", middleware.GetReqID(ctx)))
+		return nil, status.Error(codes.Unauthenticated, "Missing access token")
+	}
+
+	userId, err := i.parser.Parse(raw, token.Access)
+	if err != nil {
+		i.log.Info("Invalid access token", slog.String("method", method), slog.String("request_id", middleware.GetReqID(ctx)), sl.Error(err))
+		return nil, status.Error(codes.Unauthenticated, "Invalid access token")
+	}
+
+	return auth.WithUserId(ctx, userId), nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		if raw, ok := strings.CutPrefix(header, "Bearer "); ok && raw != "" {
+			return raw, true
+		}
+	}
+	return "", false
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
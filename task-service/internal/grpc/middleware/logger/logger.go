@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Unary gives every call a request id, taken from the "x-request-id"
+// metadata if the client sent one, logs the call when it completes and
+// turns panics into Internal errors. The id is stored where
+// middleware.GetReqID finds it, so the audit log records it like for HTTP.
+func Unary(log *slog.Logger) grpc.UnaryServerInterceptor {
+	log = log.With(
+		slog.String("component", "grpc/middleware/logger"),
+	)
+
+	log.Info("grpc logger interceptor enabled")
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		ctx = withRequestId(ctx)
+		defer finish(ctx, log, info.FullMethod, time.Now(), &err)
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream is Unary for streaming calls, which are logged when they end.
+func Stream(log *slog.Logger) grpc.StreamServerInterceptor {
+	log = log.With(
+		slog.String("component", "grpc/middleware/logger"),
+	)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := withRequestId(ss.Context())
+		defer finish(ctx, log, info.FullMethod, time.Now(), &err)
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func withRequestId(ctx context.Context) context.Context {
+	requestId := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get("x-request-id"); len(ids) > 0 {
+			requestId = ids[0]
+		}
+	}
+	if requestId == "" {
+		requestId = uuid.NewString()
+	}
+	return context.WithValue(ctx, middleware.RequestIDKey, requestId)
+}
+
+func finish(ctx context.Context, log *slog.Logger, method string, start time.Time, err *error) {
+	if rec := recover(); rec != nil {
+		log.Error("panic recovered",
+			slog.String("method", method),
+			slog.String("request_id", middleware.GetReqID(ctx)),
+			slog.Any("panic", rec),
+			slog.String("stack", string(debug.Stack())),
+		)
+		*err = status.Error(codes.Internal, "Internal error")
+	}
+
+	remoteAddr := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+
+	log.Info("call completed",
+		slog.String("method", method),
+		slog.String("remote_addr", remoteAddr),
+		slog.String("request_id", middleware.GetReqID(ctx)),
+		slog.String("code", status.Code(*err).String()),
+		slog.String("duration", time.Since(start).String()),
+	)
+}
+
+// serverStream replaces the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
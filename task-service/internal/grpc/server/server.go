@@ -0,0 +1,40 @@
+package server
+
+import (
+	"log/slog"
+	"task-service/internal/grpc/middleware/auth"
+	"task-service/internal/grpc/middleware/logger"
+	"task-service/internal/grpc/taskpb"
+	httpAuth "task-service/internal/http/middleware/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// New returns the gRPC server with the task service, the standard health
+// service and server reflection. Health checks and reflection do not need
+// an access token.
+func New(log *slog.Logger, parser httpAuth.TokenParser, tasks taskpb.TaskServiceServer) *grpc.Server {
+	authenticator := auth.New(log, parser,
+		"/"+healthpb.Health_ServiceDesc.ServiceName+"/",
+		"/grpc.reflection.",
+	)
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logger.Unary(log), authenticator.Unary()),
+		grpc.ChainStreamInterceptor(logger.Stream(log), authenticator.Stream()),
+	)
+
+	taskpb.RegisterTaskServiceServer(srv, tasks)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(taskpb.TaskService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(srv, healthServer)
+
+	reflection.Register(srv)
+
+	return srv
+}
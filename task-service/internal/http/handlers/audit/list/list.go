@@ -0,0 +1,180 @@
+package list
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	TaskId string `json:"task_id" validate:"omitempty,id_valid"`
+
+	// example: 50
+	Limit int `json:"limit" validate:"min=0,max=200"`
+
+	Cursor string `json:"cursor"`
+}
+
+// Change is the value of a field before and after the change, old is null
+// for created tasks.
+type Change struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+type Event struct {
+	// example: 42
+	Id int64 `json:"id"`
+
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	TaskId string `json:"task_id"`
+
+	// enum: create, update, delete, restore
+	Action string `json:"action"`
+
+	// User id, or the worker that made the change
+	Actor string `json:"actor"`
+
+	RequestId string            `json:"request_id,omitempty"`
+	Changes   map[string]Change `json:"changes"`
+	CreatedAt string            `json:"created_at"`
+}
+
+type Response struct {
+	response.Response
+	Events     []Event `json:"events"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type AuditLister interface {
+	ListAudit(filter domain.AuditFilter) (domain.AuditPage, error)
+}
+
+// @Summary List audit events
+// @Description List changes of all tasks of the user, newest first. Changes of deleted tasks are kept.
+// @Tags Audit
+// @Produce json
+// @Param limit query int false "Page size (max 200)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Security BearerAuth
+// @Success 200 {object} Response "Audit events retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list audit events"
+// @Router /audit [get]
+func New(log *slog.Logger, auditLister AuditLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.audit.list.New"
+
+		list(log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context()))), auditLister, w, r, "")
+	}
+}
+
+// @Summary List audit events of task
+// @Description List changes of one task, newest first. Works for deleted tasks too.
+// @Tags Audit
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Param limit query int false "Page size (max 200)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Security BearerAuth
+// @Success 200 {object} Response "Audit events retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list audit events"
+// @Router /task/{id}/audit [get]
+func NewForTask(log *slog.Logger, auditLister AuditLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.audit.list.NewForTask"
+
+		list(log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context()))), auditLister, w, r, chi.URLParam(r, "id"))
+	}
+}
+
+func list(log *slog.Logger, auditLister AuditLister, w http.ResponseWriter, r *http.Request, taskId string) {
+	req := Request{
+		TaskId: taskId,
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			log.Error("Invalid limit", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+		req.Limit = n
+	}
+
+	validate := validator.New()
+	validate.RegisterValidation("id_valid", validators.IsValidId)
+
+	if err := validate.Struct(req); err != nil {
+		log.Error("Invalid request", sl.Error(err))
+		render.JSON(w, r, response.ErrorClient("Invalid request"))
+		return
+	}
+
+	filter := domain.AuditFilter{
+		OwnerId: auth.UserId(r.Context()),
+		Limit:   req.Limit,
+		Cursor:  req.Cursor,
+	}
+	if req.TaskId != "" {
+		id := uuid.MustParse(req.TaskId)
+		filter.TaskId = &id
+	}
+
+	page, err := auditLister.ListAudit(filter)
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		log.Error("Invalid cursor", sl.Error(err))
+		render.JSON(w, r, response.ErrorClient("Invalid cursor"))
+		return
+	}
+	if err != nil {
+		log.Error("Failed to list audit events", sl.Error(err))
+		render.JSON(w, r, response.Error("Failed to list audit events"))
+		return
+	}
+
+	events := make([]Event, 0, len(page.Events))
+	for _, event := range page.Events {
+		changes := make(map[string]Change, len(event.Changes))
+		for field, change := range event.Changes {
+			changes[field] = Change{Old: change.Old, New: change.New}
+		}
+
+		events = append(events, Event{
+			Id:        event.Id,
+			TaskId:    event.TaskId.String(),
+			Action:    string(event.Action),
+			Actor:     event.Actor,
+			RequestId: event.RequestId,
+			Changes:   changes,
+			CreatedAt: event.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	log.Info("Audit events listed", slog.Int("count", len(events)))
+
+	render.JSON(w, r, Response{
+		Response:   response.StatusOK(),
+		Events:     events,
+		NextCursor: page.NextCursor,
+	})
+}
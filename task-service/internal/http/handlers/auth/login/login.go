@@ -0,0 +1,124 @@
+package login
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/token"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// swagger:model
+type Request struct {
+	// example: alice@example.com
+	Email string `json:"email" validate:"required,email"`
+
+	// example: correct horse battery staple
+	Password string `json:"password" validate:"required"`
+}
+
+type Response struct {
+	response.Response
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// example: 2025-04-21 09:15:00
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// dummyHash is compared with the password of unknown emails. It has the
+// cost of the stored hashes.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("no user has this password"), bcrypt.DefaultCost)
+
+type UserGetter interface {
+	GetUserByEmail(email string) (domain.User, error)
+}
+
+type TokenIssuer interface {
+	IssuePair(userId uuid.UUID) (token.Pair, error)
+}
+
+// @Summary Log in
+// @Description Exchange email and password for an access and a refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Success 200 {object} Response "Logged in successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Invalid email or password"
+// @Failure 500 {object} response.Response "Failed to log in"
+// @Router /auth/login [post]
+func New(log *slog.Logger, userGetter UserGetter, tokens TokenIssuer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.auth.login.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		user, err := userGetter.GetUserByEmail(req.Email)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			// Takes as long as a wrong password, so the answer time does
+			// not tell which emails are registered.
+			bcrypt.CompareHashAndPassword(dummyHash, []byte(req.Password))
+			log.Info("Unknown email")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.ErrorUnauthorized("Invalid email or password"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get user", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to log in"))
+			return
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+			log.Info("Wrong password", slog.String("UserId", user.Id.String()))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.ErrorUnauthorized("Invalid email or password"))
+			return
+		}
+
+		pair, err := tokens.IssuePair(user.Id)
+		if err != nil {
+			log.Error("Failed to issue tokens", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to log in"))
+			return
+		}
+
+		log.Info("User logged in", slog.String("UserId", user.Id.String()))
+
+		render.JSON(w, r, Response{
+			Response:     response.StatusOK(),
+			AccessToken:  pair.AccessToken,
+			RefreshToken: pair.RefreshToken,
+			ExpiresAt:    pair.ExpiresAt.UTC().Format("2006-01-02 15:04:05"),
+		})
+	}
+}
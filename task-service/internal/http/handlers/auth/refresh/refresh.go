@@ -0,0 +1,92 @@
+package refresh
+
+import (
+	"log/slog"
+	"net/http"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/token"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type Response struct {
+	response.Response
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// example: 2025-04-21 09:15:00
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+type TokenRefresher interface {
+	Parse(raw string, kind token.Kind) (uuid.UUID, error)
+	IssuePair(userId uuid.UUID) (token.Pair, error)
+}
+
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access and refresh token
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Success 200 {object} Response "Tokens refreshed successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Invalid refresh token"
+// @Failure 500 {object} response.Response "Failed to refresh tokens"
+// @Router /auth/refresh [post]
+func New(log *slog.Logger, tokens TokenRefresher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.auth.refresh.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		userId, err := tokens.Parse(req.RefreshToken, token.Refresh)
+		if err != nil {
+			log.Info("Invalid refresh token", sl.Error(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.ErrorUnauthorized("Invalid refresh token"))
+			return
+		}
+
+		pair, err := tokens.IssuePair(userId)
+		if err != nil {
+			log.Error("Failed to issue tokens", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to refresh tokens"))
+			return
+		}
+
+		log.Info("Tokens refreshed", slog.String("UserId", userId.String()))
+
+		render.JSON(w, r, Response{
+			Response:     response.StatusOK(),
+			AccessToken:  pair.AccessToken,
+			RefreshToken: pair.RefreshToken,
+			ExpiresAt:    pair.ExpiresAt.UTC().Format("2006-01-02 15:04:05"),
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package register
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// swagger:model
+type Request struct {
+	// example: alice@example.com
+	Email string `json:"email" validate:"required,email,max=255"`
+
+	// example: correct horse battery staple
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type Response struct {
+	response.Response
+
+	// example: 5d2f4b0e-3c8e-4d3a-9c39-7f1c7d4c2a10
+	UserId string `json:"user_id,omitempty"`
+}
+
+type UserSaver interface {
+	SaveUser(user domain.User) error
+}
+
+// @Summary Register user
+// @Description Create a user account
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Success 201 {object} Response "User registered successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 409 {object} response.Response "User already exists"
+// @Failure 500 {object} response.Response "Failed to register user"
+// @Router /auth/register [post]
+func New(log *slog.Logger, userSaver UserSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.auth.register.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			log.Error("Failed to hash password", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to register user"))
+			return
+		}
+
+		user := domain.User{
+			Id:           uuid.New(),
+			Email:        req.Email,
+			PasswordHash: string(hash),
+			CreatedAt:    time.Now().UTC(),
+		}
+
+		err = userSaver.SaveUser(user)
+		if errors.Is(err, domain.ErrUserExists) {
+			log.Info("User already exists")
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict("User already exists"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to save user", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to register user"))
+			return
+		}
+
+		log.Info("User registered", slog.String("UserId", user.Id.String()))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			Response: response.StatusCreated(),
+			UserId:   user.Id.String(),
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/breaker"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+const (
+	StateOK       = "ok"
+	StateDegraded = "degraded"
+	StateDown     = "down"
+)
+
+// pingTimeout bounds each backend check, so a hanging backend does not hang
+// the health check.
+const pingTimeout = 2 * time.Second
+
+type Backend struct {
+	Available bool   `json:"available"`
+	Breaker   string `json:"breaker"`
+	Error     string `json:"error,omitempty"`
+}
+
+type Response struct {
+	response.Response
+	State    string  `json:"state"`
+	Postgres Backend `json:"postgres"`
+	Redis    Backend `json:"redis"`
+}
+
+type Checker interface {
+	Ping(ctx context.Context) error
+	State() breaker.State
+}
+
+// @Summary Health check
+// @Description Check Postgres and Redis. Without Redis the service still serves from Postgres and reports "degraded"; without Postgres it reports "down" with 503.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} Response "Service is ok or degraded"
+// @Failure 503 {object} Response "Postgres is unavailable"
+// @Router /health [get]
+func New(log *slog.Logger, postgres Checker, redis Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.health.New"
+
+		log := log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		resp := Response{
+			Response: response.StatusOK(),
+			State:    StateOK,
+			Postgres: check(r.Context(), postgres),
+			Redis:    check(r.Context(), redis),
+		}
+
+		switch {
+		case !resp.Postgres.Available:
+			resp.State = StateDown
+			resp.Status = http.StatusServiceUnavailable
+			log.Warn("Postgres is unavailable", slog.String("error", resp.Postgres.Error))
+		case !resp.Redis.Available:
+			resp.State = StateDegraded
+			log.Warn("Redis is unavailable", slog.String("error", resp.Redis.Error))
+		}
+
+		render.Status(r, resp.Status)
+		render.JSON(w, r, resp)
+	}
+}
+
+func check(ctx context.Context, backend Checker) Backend {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	b := Backend{Available: true}
+	if err := backend.Ping(ctx); err != nil {
+		b.Available = false
+		b.Error = err.Error()
+	}
+	b.Breaker = backend.State().String()
+
+	return b
+}
@@ -0,0 +1,115 @@
+package change
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: Household
+	Name string `json:"name" validate:"max=255"`
+
+	// example: Everything about the house
+	Description string `json:"description"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id"`
+}
+
+type ProjectChanger interface {
+	UpdateProjectById(ownerId, id uuid.UUID, updates domain.Project) error
+}
+
+// @Summary Update project by uuid
+// @Description Rename a project or change its description
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param id path string true "Project UUID"
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 200 {object} Response "Project updated successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Project not found"
+// @Failure 409 {object} response.Response "Project already exists"
+// @Failure 500 {object} response.Response "Failed to update project"
+// @Router /project/{id} [patch]
+func New(log *slog.Logger, projectChanger ProjectChanger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.project.change.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		req.Name = strings.TrimSpace(req.Name)
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		updates := domain.Project{
+			Name:        req.Name,
+			Description: req.Description,
+		}
+
+		err := projectChanger.UpdateProjectById(auth.UserId(r.Context()), uuid.MustParse(req.Id), updates)
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Info("Project not found", slog.String("ProjectId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Project not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrProjectExists) {
+			log.Info("Project already exists", slog.String("name", req.Name))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict("Project already exists"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to update project", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to update project"))
+			return
+		}
+
+		log.Info("Project updated", slog.String("ProjectId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package get
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	TaskCount   int    `json:"task_count"`
+}
+
+type ProjectGetter interface {
+	GetProjectById(ownerId, id uuid.UUID) (domain.Project, error)
+}
+
+// @Summary Get project by uuid
+// @Description Get project by its UUID
+// @Tags Project
+// @Produce json
+// @Param id path string true "Project UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Project retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Project not found"
+// @Failure 500 {object} response.Response "Failed to get project"
+// @Router /project/{id} [get]
+func New(log *slog.Logger, projectGetter ProjectGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.project.get.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		project, err := projectGetter.GetProjectById(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Info("Project not found", slog.String("ProjectId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Project not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get project", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get project"))
+			return
+		}
+
+		log.Info("Project get", slog.String("ProjectId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response:    response.StatusOK(),
+			Id:          project.Id.String(),
+			Name:        project.Name,
+			Description: project.Description,
+			CreatedAt:   project.CreatedAt.Format("2006-01-02 15:04:05"),
+			TaskCount:   project.TaskCount,
+		})
+	}
+}
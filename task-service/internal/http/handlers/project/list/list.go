@@ -0,0 +1,73 @@
+package list
+
+import (
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Project struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	TaskCount   int    `json:"task_count"`
+}
+
+type Response struct {
+	response.Response
+	Projects []Project `json:"projects"`
+}
+
+type ProjectLister interface {
+	ListProjects(ownerId uuid.UUID) ([]domain.Project, error)
+}
+
+// @Summary List projects
+// @Description List projects of the user ordered by name
+// @Tags Project
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} Response "Projects retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list projects"
+// @Router /project [get]
+func New(log *slog.Logger, projectLister ProjectLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.project.list.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		found, err := projectLister.ListProjects(auth.UserId(r.Context()))
+		if err != nil {
+			log.Error("Failed to list projects", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list projects"))
+			return
+		}
+
+		projects := make([]Project, 0, len(found))
+		for _, project := range found {
+			projects = append(projects, Project{
+				Id:          project.Id.String(),
+				Name:        project.Name,
+				Description: project.Description,
+				CreatedAt:   project.CreatedAt.Format("2006-01-02 15:04:05"),
+				TaskCount:   project.TaskCount,
+			})
+		}
+
+		log.Info("Projects listed", slog.Int("count", len(projects)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Projects: projects,
+		})
+	}
+}
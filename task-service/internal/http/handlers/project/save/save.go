@@ -0,0 +1,106 @@
+package save
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: Home
+	Name string `json:"name" validate:"required,max=255"`
+
+	// example: Chores and errands
+	Description string `json:"description,omitempty"`
+}
+
+type Response struct {
+	response.Response
+
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	ProjectId string `json:"project_id,omitempty"`
+}
+
+type ProjectSaver interface {
+	SaveProject(project domain.Project) error
+}
+
+// @Summary Create project
+// @Description Create a project to group tasks
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 201 {object} Response "Project created successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 409 {object} response.Response "Project already exists"
+// @Failure 500 {object} response.Response "Failed to save project"
+// @Router /project [post]
+func New(log *slog.Logger, projectSaver ProjectSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.project.save.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		req.Name = strings.TrimSpace(req.Name)
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		project := domain.Project{
+			Id:          uuid.New(),
+			Name:        req.Name,
+			Description: req.Description,
+			OwnerId:     auth.UserId(r.Context()),
+			CreatedAt:   time.Now().UTC(),
+		}
+
+		err := projectSaver.SaveProject(project)
+		if errors.Is(err, domain.ErrProjectExists) {
+			log.Info("Project already exists", slog.String("name", project.Name))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict("Project already exists"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to save project", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to save project"))
+			return
+		}
+
+		log.Info("Project created", slog.String("ProjectId", project.Id.String()))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			Response:  response.StatusCreated(),
+			ProjectId: project.Id.String(),
+		})
+	}
+}
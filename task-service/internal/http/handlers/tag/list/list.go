@@ -0,0 +1,67 @@
+package list
+
+import (
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type Tag struct {
+	Name      string `json:"name"`
+	TaskCount int    `json:"task_count"`
+}
+
+type Response struct {
+	response.Response
+	Tags []Tag `json:"tags"`
+}
+
+type TagLister interface {
+	ListTags(ownerId uuid.UUID) ([]domain.TagUsage, error)
+}
+
+// @Summary List tags
+// @Description List tags of the user with the number of tasks using each, the most used first
+// @Tags Tag
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} Response "Tags retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list tags"
+// @Router /tag [get]
+func New(log *slog.Logger, tagLister TagLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.tag.list.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		found, err := tagLister.ListTags(auth.UserId(r.Context()))
+		if err != nil {
+			log.Error("Failed to list tags", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list tags"))
+			return
+		}
+
+		tags := make([]Tag, 0, len(found))
+		for _, tag := range found {
+			tags = append(tags, Tag{
+				Name:      tag.Name,
+				TaskCount: tag.TaskCount,
+			})
+		}
+
+		log.Info("Tags listed", slog.Int("count", len(tags)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Tags:     tags,
+		})
+	}
+}
@@ -0,0 +1,341 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/task/change"
+	taskDelete "task-service/internal/http/handlers/task/delete"
+	"task-service/internal/http/handlers/task/save"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/request"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/workflow"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+const maxOperations = 500
+
+// Create, Update and Delete mirror the requests of the single task handlers
+// so swag can document them; they are converted to those requests and
+// validated by their rules.
+type Create struct {
+	// example: Sample Task
+	Title string `json:"title,omitempty"`
+
+	// example: This is a sample task description.
+	Description string `json:"description,omitempty"`
+
+	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
+	// example: DAILY
+	RepeatTask string `json:"repeat_task,omitempty"`
+
+	// enum: URGENT, HIGH, MEDIUM, LOW
+	// example: HIGH
+	Priority string `json:"priority,omitempty"`
+
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	ProjectId string `json:"project_id,omitempty"`
+
+	// example: 5d2b7e0c-1f4a-4c39-8e6d-0a9b3c7f2e18
+	ParentId string `json:"parent_id,omitempty"`
+
+	// example: ["backend", "urgent"]
+	Tags []string `json:"tags,omitempty"`
+
+	// example: 2025-04-21T09:00:00Z
+	StartAt *time.Time `json:"start_at,omitempty"`
+
+	// example: 2025-04-25T18:00:00Z
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// example: Europe/Berlin
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type Update struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id"`
+
+	// example: New Task Title
+	Title string `json:"title"`
+
+	// example: This is a new task description.
+	Description string `json:"description"`
+
+	// enum: TODO, IN_PROGRESS, DONE
+	// example: DONE
+	TaskStatus string `json:"task_status"`
+
+	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
+	// example: DAILY
+	RepeatTask string `json:"repeat_task"`
+
+	// enum: URGENT, HIGH, MEDIUM, LOW
+	// example: HIGH
+	Priority string `json:"priority"`
+
+	// Replaces all tags of the task, omit to keep them
+	// example: ["backend", "urgent"]
+	Tags []string `json:"tags"`
+
+	// Omit to keep the date, null to remove it
+	// example: 2025-04-21T09:00:00Z
+	StartAt request.Time `json:"start_at" swaggertype:"string" format:"date-time"`
+
+	// Omit to keep the date, null to remove it
+	// example: 2025-04-25T18:00:00Z
+	DueAt request.Time `json:"due_at" swaggertype:"string" format:"date-time"`
+
+	// example: Europe/Berlin
+	Timezone string `json:"timezone"`
+}
+
+type Delete struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id"`
+
+	// What to do with subtasks, delete by default
+	// enum: delete, keep
+	Subtasks string `json:"subtasks"`
+}
+
+// Operation holds exactly one of create, update or delete.
+type Operation struct {
+	Create *Create `json:"create,omitempty"`
+	Update *Update `json:"update,omitempty"`
+	Delete *Delete `json:"delete,omitempty"`
+}
+
+// swagger:model
+type Request struct {
+	Operations []Operation `json:"operations"`
+}
+
+// Result is the outcome of one operation. Operations that were fine but not
+// applied because another one failed get 424.
+type Result struct {
+	response.Response
+
+	// example: 0
+	Index int `json:"index"`
+
+	// enum: create, update, delete
+	Action string `json:"action,omitempty"`
+
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id,omitempty"`
+
+	// example: 1
+	Deleted int `json:"deleted,omitempty"`
+}
+
+type Response struct {
+	response.Response
+	Results []Result `json:"results"`
+}
+
+type BatchApplier interface {
+	ApplyBatch(ctx context.Context, ownerId uuid.UUID, ops []domain.BatchOp, check domain.StatusCheck) ([]domain.BatchResult, error)
+}
+
+// @Summary Apply batch of operations
+// @Description Create, update and delete up to 500 tasks in one transaction. Operations run in order and either all of them are applied or none; every operation gets its own result.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 200 {object} Response "Batch applied successfully"
+// @Failure 400 {object} Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} Response "Task, project or parent task not found"
+// @Failure 409 {object} Response "Status transition is not allowed or the task is blocked"
+// @Failure 500 {object} Response "Failed to apply batch"
+// @Router /task/batch [post]
+func New(log *slog.Logger, batchApplier BatchApplier, wf *workflow.Workflow) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.batch.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		if len(req.Operations) == 0 || len(req.Operations) > maxOperations {
+			log.Error("Invalid request", slog.Int("operations", len(req.Operations)))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorClient("Batch must have from 1 to 500 operations"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+		validate.RegisterValidation("task_status_valid", validators.IsValidTaskStatus)
+		validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
+		validate.RegisterValidation("timezone_valid", validators.IsValidTimezone)
+		validate.RegisterValidation("priority_valid", validators.IsValidPriority)
+
+		userId := auth.UserId(r.Context())
+
+		results := make([]Result, len(req.Operations))
+		ops := make([]domain.BatchOp, len(req.Operations))
+		invalid := false
+		for i, operation := range req.Operations {
+			results[i].Index = i
+			batchOp, err := newBatchOp(validate, operation, userId)
+			results[i].Action = string(batchOp.Action)
+			if err != nil {
+				log.Error("Invalid operation", slog.Int("index", i), sl.Error(err))
+				results[i].Response = response.ErrorClient("Invalid request")
+				invalid = true
+				continue
+			}
+			ops[i] = batchOp
+		}
+
+		if invalid {
+			fail(w, r, results, http.StatusBadRequest)
+			return
+		}
+
+		applied, err := batchApplier.ApplyBatch(r.Context(), userId, ops, wf.Check)
+		var batchErr *domain.BatchError
+		if errors.As(err, &batchErr) {
+			log.Info("Batch rejected", slog.Int("index", batchErr.Index), sl.Error(err))
+			results[batchErr.Index].Response = errorResponse(batchErr.Err)
+			fail(w, r, results, results[batchErr.Index].Status)
+			return
+		}
+		if err != nil {
+			log.Error("Failed to apply batch", sl.Error(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, response.Error("Failed to apply batch"))
+			return
+		}
+
+		for i, result := range applied {
+			switch ops[i].Action {
+			case domain.BatchCreate, domain.BatchUpdate:
+				results[i].Response = response.StatusOK()
+				if ops[i].Action == domain.BatchCreate {
+					results[i].Response = response.StatusCreated()
+				}
+				results[i].Id = result.Task.Id.String()
+			case domain.BatchDelete:
+				results[i].Response = response.StatusOK()
+				results[i].Id = ops[i].Id.String()
+				results[i].Deleted = len(result.Deleted)
+			}
+		}
+
+		log.Info("Batch applied", slog.Int("operations", len(ops)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Results:  results,
+		})
+	}
+}
+
+func newBatchOp(validate *validator.Validate, operation Operation, ownerId uuid.UUID) (domain.BatchOp, error) {
+	set := 0
+	for _, present := range []bool{operation.Create != nil, operation.Update != nil, operation.Delete != nil} {
+		if present {
+			set++
+		}
+	}
+	if set != 1 {
+		return domain.BatchOp{}, errors.New("operation must have exactly one of create, update or delete")
+	}
+
+	switch {
+	case operation.Create != nil:
+		batchOp := domain.BatchOp{Action: domain.BatchCreate}
+		req := save.Request(*operation.Create)
+		if err := validate.Struct(req); err != nil {
+			return batchOp, err
+		}
+		task, err := save.CreateTask(req, ownerId)
+		batchOp.Task = task
+		return batchOp, err
+	case operation.Update != nil:
+		batchOp := domain.BatchOp{Action: domain.BatchUpdate}
+		req := change.Request(*operation.Update)
+		if err := validate.Struct(req); err != nil {
+			return batchOp, err
+		}
+		updates, err := change.CreateUpdates(req)
+		batchOp.Id = uuid.MustParse(req.Id)
+		batchOp.Task = updates
+		return batchOp, err
+	default:
+		batchOp := domain.BatchOp{Action: domain.BatchDelete}
+		req := taskDelete.Request(*operation.Delete)
+		if err := validate.Struct(req); err != nil {
+			return batchOp, err
+		}
+		batchOp.Id = uuid.MustParse(req.Id)
+		batchOp.Cascade = req.Subtasks != "keep"
+		return batchOp, nil
+	}
+}
+
+func errorResponse(err error) response.Response {
+	var transitionErr *domain.TransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		return response.ErrorConflict(transitionErr.Error())
+	case errors.Is(err, domain.ErrTaskBlocked):
+		return response.ErrorConflict("Task is blocked by unfinished tasks")
+	case errors.Is(err, domain.ErrStartAfterDue):
+		return response.ErrorClient("Start date is after due date")
+	case errors.Is(err, domain.ErrTaskNotFound):
+		return response.ErrorNotFound("Task not found")
+	case errors.Is(err, domain.ErrProjectNotFound):
+		return response.ErrorNotFound("Project not found")
+	case errors.Is(err, domain.ErrParentNotFound):
+		return response.ErrorNotFound("Parent task not found")
+	case errors.Is(err, domain.ErrTaskCycle):
+		return response.ErrorConflict("Task cannot be a subtask of itself")
+	default:
+		return response.Error("Failed to apply batch")
+	}
+}
+
+// fail marks every operation without a result as not applied and renders the
+// results with the status of the failure.
+func fail(w http.ResponseWriter, r *http.Request, results []Result, status int) {
+	for i := range results {
+		if results[i].Status == 0 {
+			results[i].Response = response.Response{
+				Status: http.StatusFailedDependency,
+				Error:  "Not applied",
+			}
+		}
+	}
+
+	render.Status(r, status)
+	render.JSON(w, r, Response{
+		Response: response.Response{
+			Status: status,
+			Error:  "Batch was not applied",
+		},
+		Results: results,
+	})
+}
@@ -0,0 +1,113 @@
+package block
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// Task that has to be DONE before this one can start
+	// example: 9a4e1c6b-2d3f-4e58-b7a0-1c2d3e4f5a6b
+	BlockerId string `json:"blocker_id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id        string `json:"id"`
+	BlockerId string `json:"blocker_id"`
+}
+
+type BlockerAdder interface {
+	AddBlocker(ownerId, id, blockerId uuid.UUID) error
+}
+
+// @Summary Add blocking task
+// @Description Make the task wait for another one: it cannot move to IN_PROGRESS or DONE until the blocker is DONE
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 200 {object} Response "Blocker added successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task or blocking task not found"
+// @Failure 409 {object} response.Response "Dependency would create a cycle"
+// @Failure 500 {object} response.Response "Failed to add blocker"
+// @Router /task/{id}/blockers [post]
+func New(log *slog.Logger, blockerAdder BlockerAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.block.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		err := blockerAdder.AddBlocker(auth.UserId(r.Context()), uuid.MustParse(req.Id), uuid.MustParse(req.BlockerId))
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrBlockerNotFound) {
+			log.Info("Blocking task not found", slog.String("BlockerId", req.BlockerId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Blocking task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrDependencyCycle) {
+			log.Error("Dependency rejected", sl.Error(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict("Dependency would create a cycle"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to add blocker", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to add blocker"))
+			return
+		}
+
+		log.Info("Blocker added", slog.String("TaskId", req.Id), slog.String("BlockerId", req.BlockerId))
+
+		render.JSON(w, r, Response{
+			Response:  response.StatusOK(),
+			Id:        req.Id,
+			BlockerId: req.BlockerId,
+		})
+	}
+}
@@ -1,126 +1,236 @@
-package change
-
-import (
-	"encoding/json"
-	"log/slog"
-	"net/http"
-	"task-service/domain"
-	"task-service/internal/http/handlers/validators"
-	"task-service/internal/lib/api/response"
-	"task-service/internal/lib/logger/sl"
-	"task-service/internal/repo/redis"
-	"time"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/render"
-	"github.com/go-playground/validator"
-	"github.com/google/uuid"
-)
-
-// swagger:model
-type Request struct {
-	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
-	Id string `json:"id" validate:"id_valid,required"`
-
-	// example: New Task Title
-	Title string `json:"title"`
-
-	// example: This is a new task description.
-	Description string `json:"description"`
-
-	// enum: TODO, IN_PROGRESS, DONE
-	// example: TODO
-	TaskStatus string `json:"task_status" validate:"task_status_valid"`
-
-	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
-	// example: DAILY
-	RepeatTask string `json:"repeat_task" validate:"repeat_task_valid"`
-}
-
-type Response struct {
-	response.Response
-	Id string `json:"id" validate:"id_valid,required"`
-}
-
-type TaskChanger interface {
-	UpdateTaskById(id uuid.UUID, updates domain.Task) error
-	GetTaskById(id uuid.UUID) (domain.Task, error)
-}
-
-// @Summary Update task by uuid
-// @Description Update task by its UUID
-// @Tags Task
-// @Accept json
-// @Produce json
-// @Param request body Request true "Request"
-// @Success 200 {object} Response "Task updated successfully"
-// @Failure 400 {object} response.Response "Invalid request"
-// @Failure 500 {object} response.Response "Failed to update task"
-// @Router /task [patch]
-func New(log *slog.Logger, taskChanger TaskChanger, rdb *redis.RedisDB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.task.change.New"
-
-		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
-
-		req := Request{
-			Id: chi.URLParam(r, "id"),
-		}
-
-		if err := render.DecodeJSON(r.Body, &req); err != nil {
-			log.Error("Failed to decode request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Invalid request"))
-			return
-		}
-
-		validate := validator.New()
-		validate.RegisterValidation("id_valid", validators.IsValidId)
-		validate.RegisterValidation("task_status_valid", validators.IsValidTaskStatus)
-		validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
-
-		if err := validate.Struct(req); err != nil {
-			log.Error("Invalid request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Invalid request"))
-			return
-		}
-
-		updates := domain.Task{
-			Title:       req.Title,
-			Description: req.Description,
-			TaskStatus:  domain.TaskStatus(req.TaskStatus),
-			RepeatTask:  domain.TaskRepeatType(req.RepeatTask),
-		}
-
-		err := taskChanger.UpdateTaskById(uuid.MustParse(req.Id), updates)
-		if err != nil {
-			log.Error("Failed to update task", sl.Error(err))
-			render.JSON(w, r, response.Error("Failed to update task"))
-			return
-		}
-
-		updatedTask, err := taskChanger.GetTaskById(uuid.MustParse(req.Id))
-		if err != nil {
-			log.Error("Failed to load updated task for Redis", sl.Error(err))
-		} else {
-			taskJSON, err := json.Marshal(updatedTask)
-			if err != nil {
-				log.Error("Failed to marshal updated task", sl.Error(err))
-			} else {
-				cacheKey := "task:" + req.Id
-				if err := rdb.Set(r.Context(), cacheKey, string(taskJSON), 5*time.Minute); err != nil {
-					log.Error("Failed to update task in Redis", sl.Error(err))
-				} else {
-					log.Info("Task updated in Redis cache", slog.String("TaskId", req.Id))
-				}
-			}
-		}
-
-		log.Info("Task updated successfully", slog.String("TaskId", req.Id))
-
-		render.JSON(w, r, Response{
-			Response: response.StatusOK(),
-			Id:       req.Id,
-		})
-	}
-}
+package change
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/request"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/etag"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/workflow"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: New Task Title
+	Title string `json:"title"`
+
+	// example: This is a new task description.
+	Description string `json:"description"`
+
+	// Omit to keep the status
+	// enum: TODO, IN_PROGRESS, DONE
+	// example: TODO
+	TaskStatus string `json:"task_status" validate:"omitempty,task_status_valid"`
+
+	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
+	// example: DAILY
+	RepeatTask string `json:"repeat_task" validate:"repeat_task_valid"`
+
+	// enum: URGENT, HIGH, MEDIUM, LOW
+	// example: HIGH
+	Priority string `json:"priority" validate:"priority_valid"`
+
+	// Replaces all tags of the task, omit to keep them
+	// example: ["backend", "urgent"]
+	Tags []string `json:"tags" validate:"max=20,dive,required,max=64"`
+
+	// Omit to keep the date, null to remove it
+	// example: 2025-04-21T09:00:00Z
+	StartAt request.Time `json:"start_at" swaggertype:"string" format:"date-time"`
+
+	// Omit to keep the date, null to remove it
+	// example: 2025-04-25T18:00:00Z
+	DueAt request.Time `json:"due_at" swaggertype:"string" format:"date-time"`
+
+	// IANA time zone the series repeats in, omit to keep it
+	// example: Europe/Berlin
+	Timezone string `json:"timezone" validate:"timezone_valid"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Blocker struct {
+	Id         string `json:"id"`
+	Title      string `json:"title"`
+	TaskStatus string `json:"task_status"`
+}
+
+// BlockedResponse lists the unfinished tasks that keep the task from moving
+// to IN_PROGRESS or DONE.
+type BlockedResponse struct {
+	response.Response
+	Blockers []Blocker `json:"blockers"`
+}
+
+// NewBlockedResponse is the 409 of a status change refused because of the
+// blockers.
+func NewBlockedResponse(found []domain.Task) BlockedResponse {
+	blockers := make([]Blocker, 0, len(found))
+	for _, task := range found {
+		blockers = append(blockers, Blocker{
+			Id:         task.Id.String(),
+			Title:      task.Title,
+			TaskStatus: string(task.TaskStatus),
+		})
+	}
+
+	return BlockedResponse{
+		Response: response.ErrorConflict("Task is blocked by unfinished tasks"),
+		Blockers: blockers,
+	}
+}
+
+type TaskChanger interface {
+	UpdateTaskById(ctx context.Context, ownerId, id uuid.UUID, updates domain.Task, check domain.StatusCheck) error
+}
+
+// @Summary Update task by uuid
+// @Description Update task by its UUID
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Param If-Match header string false "ETag of the task, the task is only updated if it was not changed since"
+// @Security BearerAuth
+// @Success 200 {object} Response "Task updated successfully"
+// @Failure 400 {object} response.Response "Invalid request or start date after due date"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found"
+// @Failure 409 {object} BlockedResponse "Status transition is not allowed or the task is blocked"
+// @Failure 412 {object} response.Response "Task was changed"
+// @Failure 500 {object} response.Response "Failed to update task"
+// @Router /task [patch]
+func New(log *slog.Logger, taskChanger TaskChanger, wf *workflow.Workflow) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.change.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+		validate.RegisterValidation("task_status_valid", validators.IsValidTaskStatus)
+		validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
+		validate.RegisterValidation("timezone_valid", validators.IsValidTimezone)
+		validate.RegisterValidation("priority_valid", validators.IsValidPriority)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		updates, err := CreateUpdates(req)
+		if err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		version, ok := etag.Version(r.Header.Get("If-Match"))
+		if !ok {
+			log.Info("Precondition failed", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, response.ErrorPreconditionFailed("Task was changed"))
+			return
+		}
+		updates.Version = version
+
+		err = taskChanger.UpdateTaskById(r.Context(), auth.UserId(r.Context()), uuid.MustParse(req.Id), updates, wf.Check)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			log.Info("Precondition failed", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, response.ErrorPreconditionFailed("Task was changed"))
+			return
+		}
+		if errors.Is(err, domain.ErrStartAfterDue) {
+			log.Info("Start date is after due date", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusBadRequest)
+			render.JSON(w, r, response.ErrorClient("Start date is after due date"))
+			return
+		}
+		var transitionErr *domain.TransitionError
+		if errors.As(err, &transitionErr) {
+			log.Error("Status transition rejected", sl.Error(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict(transitionErr.Error()))
+			return
+		}
+		var blockedErr *domain.BlockedError
+		if errors.As(err, &blockedErr) {
+			log.Info("Task is blocked", slog.String("TaskId", req.Id), slog.Int("blockers", len(blockedErr.Blockers)))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, NewBlockedResponse(blockedErr.Blockers))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to update task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to update task"))
+			return
+		}
+
+		log.Info("Task updated successfully", slog.String("TaskId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		})
+	}
+}
+
+// CreateUpdates turns the request into the updates of UpdateTaskById. Dates
+// that are both given are checked here; a single one is checked against the
+// other date of the task when it is updated.
+func CreateUpdates(req Request) (domain.Task, error) {
+	if req.StartAt.Value != nil && req.DueAt.Value != nil && req.StartAt.Value.After(*req.DueAt.Value) {
+		return domain.Task{}, domain.ErrStartAfterDue
+	}
+
+	return domain.Task{
+		Title:       req.Title,
+		Description: req.Description,
+		TaskStatus:  domain.TaskStatus(req.TaskStatus),
+		RepeatTask:  domain.TaskRepeatType(req.RepeatTask),
+		Priority:    domain.TaskPriority(req.Priority),
+		StartAt:     req.StartAt.Value,
+		DueAt:       req.DueAt.Value,
+		Tags:        domain.NormalizeTags(req.Tags),
+		Timezone:    req.Timezone,
+
+		ClearStartAt: req.StartAt.Clear(),
+		ClearDueAt:   req.DueAt.Clear(),
+	}, nil
+}
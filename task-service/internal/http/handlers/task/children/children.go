@@ -0,0 +1,117 @@
+package children
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Task struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	TaskStatus  string `json:"task_status"`
+	CreatedAt   string `json:"created_at"`
+	RepeatTask  string `json:"repeat_task"`
+	StartAt     string `json:"start_at,omitempty"`
+	DueAt       string `json:"due_at,omitempty"`
+	Overdue     bool   `json:"overdue"`
+}
+
+type Response struct {
+	response.Response
+	Id       string `json:"id"`
+	Children []Task `json:"children"`
+}
+
+type ChildrenGetter interface {
+	GetTaskChildren(ownerId, id uuid.UUID) ([]domain.Task, error)
+}
+
+// @Summary Get subtasks
+// @Description Get the direct subtasks of a task, oldest first
+// @Tags Task
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Subtasks retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found"
+// @Failure 500 {object} response.Response "Failed to get subtasks"
+// @Router /task/{id}/children [get]
+func New(log *slog.Logger, childrenGetter ChildrenGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.children.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		found, err := childrenGetter.GetTaskChildren(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get subtasks", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get subtasks"))
+			return
+		}
+
+		now := time.Now()
+		children := make([]Task, 0, len(found))
+		for _, task := range found {
+			children = append(children, Task{
+				Id:          task.Id.String(),
+				Title:       task.Title,
+				Description: task.Description,
+				TaskStatus:  string(task.TaskStatus),
+				CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
+				RepeatTask:  string(task.RepeatTask),
+				StartAt:     response.FormatTime(task.StartAt),
+				DueAt:       response.FormatTime(task.DueAt),
+				Overdue:     task.IsOverdue(now),
+			})
+		}
+
+		log.Info("Subtasks get", slog.String("TaskId", req.Id), slog.Int("count", len(children)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+			Children: children,
+		})
+	}
+}
@@ -1,82 +1,114 @@
-package delete
-
-import (
-	"log/slog"
-	"net/http"
-	"task-service/internal/http/handlers/validators"
-	"task-service/internal/lib/api/response"
-	"task-service/internal/lib/logger/sl"
-	"task-service/internal/repo/redis"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/render"
-	"github.com/go-playground/validator"
-	"github.com/google/uuid"
-)
-
-// swagger:model
-type Request struct {
-	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
-	Id string `json:"id" validate:"id_valid,required"`
-}
-
-type Response struct {
-	response.Response
-	Id string `json:"id" validate:"id_valid,required"`
-}
-
-type taskDeleter interface {
-	DeleteTaskById(id uuid.UUID) error
-}
-
-// @Summary Delete task by uuid
-// @Description Delete task by its UUID
-// @Tags Task
-// @Accept json
-// @Produce json
-// @Param request body Request true "Request"
-// @Success 200 {object} Response "Task deleted successfully"
-// @Failure 400 {object} response.Response "Invalid request"
-// @Failure 500 {object} response.Response "Failed to delete task"
-// @Router /task [delete]
-func New(log *slog.Logger, taskDeleter taskDeleter, rdb *redis.RedisDB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.task.delete.New"
-
-		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
-
-		req := Request{
-			Id: chi.URLParam(r, "id"),
-		}
-
-		validate := validator.New()
-		validate.RegisterValidation("id_valid", validators.IsValidId)
-
-		if err := validate.Struct(req); err != nil {
-			log.Error("Invalid request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Invalid request"))
-			return
-		}
-
-		err := taskDeleter.DeleteTaskById(uuid.MustParse(req.Id))
-		if err != nil {
-			log.Error("Failed to delete task", sl.Error(err))
-			render.JSON(w, r, response.Error("Failed to delete task"))
-			return
-		}
-
-		err = rdb.Delete(r.Context(), req.Id)
-		if err != nil {
-			log.Error("Failed to delete task from Redis", sl.Error(err))
-		}
-		log.Info("Task deleted from Redis", slog.String("TaskId", req.Id))
-
-		log.Info("Task deleted", slog.String("TaskId", req.Id))
-
-		render.JSON(w, r, Response{
-			Response: response.StatusOK(),
-			Id:       req.Id,
-		})
-	}
-}
+package delete
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/etag"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// enum: delete, keep
+	Subtasks string `json:"subtasks" validate:"omitempty,oneof=delete keep"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: 3
+	Deleted int `json:"deleted"`
+}
+
+type TaskDeleter interface {
+	DeleteTaskById(ctx context.Context, ownerId, id uuid.UUID, cascade bool, version int64) ([]uuid.UUID, error)
+}
+
+// @Summary Delete task by uuid
+// @Description Move task to the trash by its UUID. Subtasks are deleted with it unless subtasks=keep, then they move up to the parent of the deleted task. Deleted tasks can be restored until they are purged.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Param subtasks query string false "What to do with subtasks" Enums(delete, keep)
+// @Param If-Match header string false "ETag of the task, the task is only deleted if it was not changed since"
+// @Security BearerAuth
+// @Success 200 {object} Response "Task deleted successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found"
+// @Failure 412 {object} response.Response "Task was changed"
+// @Failure 500 {object} response.Response "Failed to delete task"
+// @Router /task [delete]
+func New(log *slog.Logger, taskDeleter TaskDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.delete.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id:       chi.URLParam(r, "id"),
+			Subtasks: r.URL.Query().Get("subtasks"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		version, ok := etag.Version(r.Header.Get("If-Match"))
+		if !ok {
+			log.Info("Precondition failed", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, response.ErrorPreconditionFailed("Task was changed"))
+			return
+		}
+
+		deleted, err := taskDeleter.DeleteTaskById(r.Context(), auth.UserId(r.Context()), uuid.MustParse(req.Id), req.Subtasks != "keep", version)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			log.Info("Precondition failed", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusPreconditionFailed)
+			render.JSON(w, r, response.ErrorPreconditionFailed("Task was changed"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to delete task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to delete task"))
+			return
+		}
+
+		log.Info("Task deleted", slog.String("TaskId", req.Id), slog.Int("deleted", len(deleted)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+			Deleted:  len(deleted),
+		})
+	}
+}
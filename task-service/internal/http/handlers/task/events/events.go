@@ -0,0 +1,221 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/hub"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+const (
+	// heartbeat keeps proxies from closing idle streams.
+	heartbeat = 15 * time.Second
+	// buffer is how many live events a stream may fall behind before it is
+	// closed; the client then resumes with Last-Event-ID.
+	buffer = 256
+)
+
+// swagger:model
+type Request struct {
+	ProjectId string `validate:"omitempty,id_valid"`
+
+	// enum: TODO, IN_PROGRESS, DONE
+	TaskStatus []string `validate:"dive,task_status_valid"`
+
+	LastEventId int64 `validate:"min=0"`
+}
+
+type EventLister interface {
+	ListEvents(ownerId uuid.UUID, after int64, limit int) ([]domain.Event, error)
+}
+
+type Subscriber interface {
+	Subscribe(ownerId uuid.UUID, buffer int) *hub.Client
+	Unsubscribe(client *hub.Client)
+}
+
+// @Summary Stream task events
+// @Description Server-Sent Events stream of changes of the user's tasks. Every event has the outbox id as its id, TaskCreated, TaskUpdated or TaskDeleted as its name and the task after the change as its data. A task matches the filters if it matched them before or after the change, so a board can remove tasks that left it. After a reconnect the events since Last-Event-ID are sent first.
+// @Tags Task
+// @Produce text/event-stream
+// @Param project_id query string false "Project UUID"
+// @Param task_status query string false "Comma separated statuses (TODO, IN_PROGRESS, DONE)"
+// @Param last_event_id query int false "Resume after this event, for clients that cannot set Last-Event-ID"
+// @Param Last-Event-ID header int false "Resume after this event"
+// @Security BearerAuth
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to stream events"
+// @Router /task/events [get]
+func New(log *slog.Logger, eventLister EventLister, subscriber Subscriber) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.events.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		query := r.URL.Query()
+
+		req := Request{
+			ProjectId:  query.Get("project_id"),
+			TaskStatus: splitList(query.Get("task_status")),
+		}
+
+		lastEventId := r.Header.Get("Last-Event-ID")
+		if lastEventId == "" {
+			lastEventId = query.Get("last_event_id")
+		}
+		if lastEventId != "" {
+			n, err := strconv.ParseInt(lastEventId, 10, 64)
+			if err != nil {
+				log.Error("Invalid last event id", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			req.LastEventId = n
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+		validate.RegisterValidation("task_status_valid", validators.IsValidTaskStatus)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			log.Error("Streaming is not supported")
+			render.JSON(w, r, response.Error("Failed to stream events"))
+			return
+		}
+
+		// The stream outlives the server timeouts. Past the read deadline
+		// the server would also cancel the request context and end it.
+		rc := http.NewResponseController(w)
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+			log.Error("Failed to clear write deadline", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to stream events"))
+			return
+		}
+		if err := rc.SetReadDeadline(time.Time{}); err != nil {
+			log.Error("Failed to clear read deadline", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to stream events"))
+			return
+		}
+
+		ownerId := auth.UserId(r.Context())
+		match := newFilter(req)
+
+		// Subscribe before reading missed events, so nothing published in
+		// between is lost.
+		client := subscriber.Subscribe(ownerId, buffer)
+		defer subscriber.Unsubscribe(client)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		log.Info("Event stream opened", slog.Int64("last_event_id", req.LastEventId))
+
+		send := func(event domain.Event) error {
+			if !match(event) {
+				return nil
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, event.Payload); err != nil {
+				return err
+			}
+			flusher.Flush()
+			return nil
+		}
+
+		resume, err := hub.Replay(eventLister, ownerId, req.LastEventId, send)
+		if errors.Is(err, hub.ErrReplay) {
+			log.Error("Failed to list missed events", sl.Error(err))
+			return
+		}
+		if err != nil {
+			log.Info("Event stream closed", sl.Error(err))
+			return
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				log.Info("Event stream closed")
+				return
+			case <-client.Done():
+				log.Info("Event stream dropped, closing", sl.Error(client.Err()))
+				return
+			case <-ticker.C:
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					log.Info("Event stream closed", sl.Error(err))
+					return
+				}
+				flusher.Flush()
+			case event := <-client.Events():
+				if !resume.Live(event) {
+					continue
+				}
+				if err := send(event); err != nil {
+					log.Info("Event stream closed", sl.Error(err))
+					return
+				}
+			}
+		}
+	}
+}
+
+// newFilter returns whether an event matches the request. Both the state
+// after the change and the changed fields before it are checked.
+func newFilter(req Request) func(domain.Event) bool {
+	return func(event domain.Event) bool {
+		if req.ProjectId == "" && len(req.TaskStatus) == 0 {
+			return true
+		}
+
+		var task domain.TaskEvent
+		if err := json.Unmarshal(event.Payload, &task); err != nil {
+			return false
+		}
+
+		return task.Matches(req.ProjectId, req.TaskStatus)
+	}
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
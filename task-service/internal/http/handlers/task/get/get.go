@@ -1,120 +1,179 @@
-package get
-
-import (
-	"encoding/json"
-	"log/slog"
-	"net/http"
-	"task-service/domain"
-	"task-service/internal/http/handlers/validators"
-	"task-service/internal/lib/api/response"
-	"task-service/internal/lib/logger/sl"
-	"task-service/internal/repo/redis"
-	"time"
-
-	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/render"
-	"github.com/go-playground/validator"
-	"github.com/google/uuid"
-)
-
-// swagger:model
-type Request struct {
-	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
-	Id string `json:"id" validate:"id_valid,required"`
-}
-
-type Response struct {
-	response.Response
-	Id          string `json:"id" validate:"id_valid,required"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	TaskStatus  string `json:"task_status"`
-	CreatedAt   string `json:"created_at"`
-	RepeatTask  string `json:"repeat_task" validate:"repeat_task_valid"`
-}
-
-type TaskGetter interface {
-	GetTaskById(id uuid.UUID) (domain.Task, error)
-}
-
-// @Summary Get task by uuid
-// @Description Get task by its UUID
-// @Tags Task
-// @Accept json
-// @Produce json
-// @Param request body Request true "Request"
-// @Success 200 {object} Response "Task retrieved successfully"
-// @Failure 400 {object} response.Response "Invalid request"
-// @Failure 500 {object} response.Response "Failed to save task"
-// @Router /task [post]
-func New(log *slog.Logger, taskGetter TaskGetter, rdb *redis.RedisDB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.task.get.New"
-
-		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
-
-		req := Request{
-			Id: chi.URLParam(r, "id"),
-		}
-
-		validate := validator.New()
-		validate.RegisterValidation("id_valid", validators.IsValidId)
-
-		if err := validate.Struct(req); err != nil {
-			log.Error("Invalid request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Invalid request"))
-			return
-		}
-
-		ctx := r.Context()
-		cached, err := rdb.Get(ctx, req.Id)
-		if err != nil {
-			log.Info("Failed to get task from Redis", sl.Error(err))
-		}
-		if cached != "" {
-			var task domain.Task
-			if err := json.Unmarshal([]byte(cached), &task); err == nil {
-				log.Info("Task retrieved from Redis", slog.String("TaskId", task.Id.String()))
-				render.JSON(w, r, Response{
-					Response:    response.StatusOK(),
-					Id:          task.Id.String(),
-					Title:       task.Title,
-					Description: task.Description,
-					TaskStatus:  string(task.TaskStatus),
-					CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
-					RepeatTask:  string(task.RepeatTask),
-				})
-				return
-			}
-		}
-		log.Info("Task not found in Redis, fetching from database")
-
-		task, err := taskGetter.GetTaskById(uuid.MustParse(req.Id))
-		if err != nil {
-			log.Error("Failed to get task", sl.Error(err))
-			render.JSON(w, r, response.Error("Failed to get task"))
-			return
-		}
-
-		taskJSON, err := json.Marshal(task)
-		if err != nil {
-			log.Error("Failed to marshal task", sl.Error(err))
-		} else {
-			if err := rdb.Set(ctx, req.Id, string(taskJSON), 5*time.Minute); err != nil {
-				log.Error("Failed to set task in Redis", sl.Error(err))
-			}
-		}
-
-		log.Info("Task get", slog.String("TaskId", task.Id.String()))
-		render.JSON(w, r, Response{
-			Response:    response.StatusOK(),
-			Id:          task.Id.String(),
-			Title:       task.Title,
-			Description: task.Description,
-			TaskStatus:  string(task.TaskStatus),
-			CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
-			RepeatTask:  string(task.RepeatTask),
-		})
-	}
-}
+package get
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/etag"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id          string    `json:"id" validate:"id_valid,required"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	TaskStatus  string    `json:"task_status"`
+	Priority    string    `json:"priority"`
+	CreatedAt   string    `json:"created_at"`
+	RepeatTask  string    `json:"repeat_task" validate:"repeat_task_valid"`
+	ProjectId   string    `json:"project_id,omitempty"`
+	ParentId    string    `json:"parent_id,omitempty"`
+	Tags        []string  `json:"tags"`
+	Version     int64     `json:"version"`
+	SeriesId    string    `json:"series_id"`
+	Occurrence  int       `json:"occurrence"`
+	Timezone    string    `json:"timezone,omitempty"`
+	StartAt     string    `json:"start_at,omitempty"`
+	DueAt       string    `json:"due_at,omitempty"`
+	Overdue     bool      `json:"overdue"`
+	Progress    *Progress `json:"progress,omitempty"`
+}
+
+// Progress rolls up the subtasks at every depth. It is omitted for tasks
+// without subtasks.
+type Progress struct {
+	Total   int `json:"total"`
+	Done    int `json:"done"`
+	Percent int `json:"percent"`
+}
+
+type TaskGetter interface {
+	GetTaskById(ownerId, id uuid.UUID) (domain.Task, error)
+	GetTaskProgress(ownerId, id uuid.UUID) (domain.Progress, error)
+}
+
+// @Summary Get task by uuid
+// @Description Get task by its UUID
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Param If-None-Match header string false "ETag of a copy of the task the client already has"
+// @Security BearerAuth
+// @Success 200 {object} Response "Task retrieved successfully"
+// @Header 200 {string} ETag "Entity tag of the task, send it as If-Match to update or delete the task"
+// @Success 304 "Task was not changed"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found"
+// @Failure 500 {object} response.Response "Failed to save task"
+// @Router /task [post]
+func New(log *slog.Logger, taskGetter TaskGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.get.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		ctx := r.Context()
+		userId := auth.UserId(ctx)
+
+		task, err := taskGetter.GetTaskById(userId, uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get task"))
+			return
+		}
+
+		progress, err := taskGetter.GetTaskProgress(userId, task.Id)
+		if err != nil {
+			log.Error("Failed to get task progress", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get task"))
+			return
+		}
+
+		log.Info("Task get", slog.String("TaskId", task.Id.String()))
+		writeTask(w, r, task, progress)
+	}
+}
+
+// writeTask renders the task with its ETag, or only answers 304 Not Modified
+// if the client already has this version.
+func writeTask(w http.ResponseWriter, r *http.Request, task domain.Task, progress domain.Progress) {
+	now := time.Now()
+	tag := etag.Task(task, progress, now)
+
+	w.Header().Set("ETag", tag)
+	if etag.NoneMatch(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	render.JSON(w, r, newResponse(task, progress, now))
+}
+
+func newResponse(task domain.Task, progress domain.Progress, now time.Time) Response {
+	resp := Response{
+		Response:    response.StatusOK(),
+		Id:          task.Id.String(),
+		Title:       task.Title,
+		Description: task.Description,
+		TaskStatus:  string(task.TaskStatus),
+		Priority:    string(task.Priority),
+		CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
+		RepeatTask:  string(task.RepeatTask),
+		ProjectId:   formatId(task.ProjectId),
+		ParentId:    formatId(task.ParentId),
+		Tags:        task.Tags,
+		Version:     task.Version,
+		SeriesId:    task.SeriesId.String(),
+		Occurrence:  task.Occurrence,
+		Timezone:    task.Timezone,
+		StartAt:     response.FormatTime(task.StartAt),
+		DueAt:       response.FormatTime(task.DueAt),
+		Overdue:     task.IsOverdue(now),
+	}
+
+	if progress.Total > 0 {
+		resp.Progress = &Progress{
+			Total:   progress.Total,
+			Done:    progress.Done,
+			Percent: progress.Percent(),
+		}
+	}
+
+	return resp
+}
+
+func formatId(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
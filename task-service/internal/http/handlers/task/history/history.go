@@ -0,0 +1,97 @@
+package history
+
+import (
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type StatusChange struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Actor     string `json:"actor,omitempty"`
+	ChangedAt string `json:"changed_at"`
+}
+
+type Response struct {
+	response.Response
+	Id      string         `json:"id"`
+	History []StatusChange `json:"history"`
+}
+
+type HistoryGetter interface {
+	GetStatusHistory(ownerId, id uuid.UUID) ([]domain.StatusChange, error)
+}
+
+// @Summary Get task status history
+// @Description Get the status timeline of a task, oldest transition first
+// @Tags Task
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Status history retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to get status history"
+// @Router /task/{id}/history [get]
+func New(log *slog.Logger, historyGetter HistoryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.history.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		changes, err := historyGetter.GetStatusHistory(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if err != nil {
+			log.Error("Failed to get status history", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get status history"))
+			return
+		}
+
+		history := make([]StatusChange, 0, len(changes))
+		for _, change := range changes {
+			history = append(history, StatusChange{
+				From:      string(change.From),
+				To:        string(change.To),
+				Actor:     change.Actor,
+				ChangedAt: change.ChangedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+
+		log.Info("Status history get", slog.String("TaskId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+			History:  history,
+		})
+	}
+}
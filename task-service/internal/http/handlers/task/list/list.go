@@ -0,0 +1,268 @@
+package list
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	ProjectId string `validate:"omitempty,id_valid"`
+
+	// enum: TODO, IN_PROGRESS, DONE
+	TaskStatus []string `validate:"dive,task_status_valid"`
+
+	// enum: URGENT, HIGH, MEDIUM, LOW
+	Priority []string `validate:"dive,priority_valid"`
+
+	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
+	RepeatTask []string `validate:"dive,repeat_task_valid"`
+
+	Tags []string `validate:"max=20,dive,max=64"`
+
+	// enum: all, any
+	TagMode string `validate:"omitempty,oneof=all any"`
+
+	Title string
+
+	// enum: id, title, description, task_status, priority, created_at, repeat_task, start_at, due_at
+	Sort string `validate:"omitempty,oneof=id title description task_status priority created_at repeat_task start_at due_at"`
+
+	// enum: asc, desc
+	Order string `validate:"omitempty,oneof=asc desc"`
+
+	Limit int `validate:"min=0,max=200"`
+
+	Cursor string
+}
+
+type Task struct {
+	Id          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	TaskStatus  string   `json:"task_status"`
+	Priority    string   `json:"priority"`
+	CreatedAt   string   `json:"created_at"`
+	RepeatTask  string   `json:"repeat_task"`
+	ProjectId   string   `json:"project_id,omitempty"`
+	ParentId    string   `json:"parent_id,omitempty"`
+	Tags        []string `json:"tags"`
+	StartAt     string   `json:"start_at,omitempty"`
+	DueAt       string   `json:"due_at,omitempty"`
+	Overdue     bool     `json:"overdue"`
+}
+
+type Response struct {
+	response.Response
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type TaskLister interface {
+	ListTasks(filter domain.TaskFilter) (domain.TaskPage, error)
+}
+
+// @Summary List tasks
+// @Description List tasks with filtering, sorting and cursor pagination
+// @Tags Task
+// @Produce json
+// @Param project_id query string false "Project UUID"
+// @Param task_status query string false "Comma separated statuses (TODO, IN_PROGRESS, DONE)"
+// @Param priority query string false "Comma separated priorities (URGENT, HIGH, MEDIUM, LOW)"
+// @Param repeat_task query string false "Comma separated repeat types (DAILY, WEEKLY, MONTHLY, YEARLY, NEVER)"
+// @Param tags query string false "Comma separated tags"
+// @Param tag_mode query string false "Match tasks with all (default) or any of the tags" Enums(all, any)
+// @Param created_from query string false "Created at or after (RFC3339)"
+// @Param created_to query string false "Created before (RFC3339)"
+// @Param title query string false "Title substring"
+// @Param sort query string false "Sort column, priority then due date by default" Enums(id, title, description, task_status, priority, created_at, repeat_task, start_at, due_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param limit query int false "Page size (max 200)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Security BearerAuth
+// @Success 200 {object} Response "Tasks retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list tasks"
+// @Router /task [get]
+func New(log *slog.Logger, taskLister TaskLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.list.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		query := r.URL.Query()
+
+		req := Request{
+			ProjectId:  query.Get("project_id"),
+			TaskStatus: splitList(query.Get("task_status")),
+			Priority:   splitList(query.Get("priority")),
+			RepeatTask: splitList(query.Get("repeat_task")),
+			Tags:       splitList(query.Get("tags")),
+			TagMode:    strings.ToLower(query.Get("tag_mode")),
+			Title:      query.Get("title"),
+			Sort:       query.Get("sort"),
+			Order:      strings.ToLower(query.Get("order")),
+			Cursor:     query.Get("cursor"),
+		}
+
+		if limit := query.Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				log.Error("Invalid limit", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			req.Limit = n
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+		validate.RegisterValidation("task_status_valid", validators.IsValidTaskStatus)
+		validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
+		validate.RegisterValidation("priority_valid", validators.IsValidPriority)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		filter, err := CreateFilter(req, query.Get("created_from"), query.Get("created_to"))
+		if err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		filter.OwnerId = auth.UserId(r.Context())
+
+		page, err := taskLister.ListTasks(filter)
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			log.Error("Invalid cursor", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid cursor"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to list tasks", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list tasks"))
+			return
+		}
+
+		now := time.Now()
+		tasks := make([]Task, 0, len(page.Tasks))
+		for _, task := range page.Tasks {
+			tasks = append(tasks, Task{
+				Id:          task.Id.String(),
+				Title:       task.Title,
+				Description: task.Description,
+				TaskStatus:  string(task.TaskStatus),
+				Priority:    string(task.Priority),
+				CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
+				RepeatTask:  string(task.RepeatTask),
+				ProjectId:   formatId(task.ProjectId),
+				ParentId:    formatId(task.ParentId),
+				Tags:        task.Tags,
+				StartAt:     response.FormatTime(task.StartAt),
+				DueAt:       response.FormatTime(task.DueAt),
+				Overdue:     task.IsOverdue(now),
+			})
+		}
+
+		log.Info("Tasks listed", slog.Int("count", len(tasks)))
+
+		render.JSON(w, r, Response{
+			Response:   response.StatusOK(),
+			Tasks:      tasks,
+			NextCursor: page.NextCursor,
+		})
+	}
+}
+
+func CreateFilter(req Request, createdFrom, createdTo string) (domain.TaskFilter, error) {
+	filter := domain.TaskFilter{
+		Title:  req.Title,
+		SortBy: req.Sort,
+		Order:  domain.SortOrder(strings.ToUpper(req.Order)),
+		Limit:  req.Limit,
+		Cursor: req.Cursor,
+	}
+
+	if req.ProjectId != "" {
+		projectId, err := uuid.Parse(req.ProjectId)
+		if err != nil {
+			return domain.TaskFilter{}, err
+		}
+		filter.ProjectId = &projectId
+	}
+
+	if tags := domain.NormalizeTags(req.Tags); len(tags) > 0 {
+		filter.Tags = tags
+		filter.TagMode = domain.TagMode(strings.ToUpper(req.TagMode))
+	}
+
+	for _, status := range req.TaskStatus {
+		filter.Statuses = append(filter.Statuses, domain.TaskStatus(status))
+	}
+
+	for _, priority := range req.Priority {
+		filter.Priorities = append(filter.Priorities, domain.TaskPriority(priority))
+	}
+
+	for _, repeat := range req.RepeatTask {
+		filter.RepeatTypes = append(filter.RepeatTypes, domain.TaskRepeatType(repeat))
+	}
+
+	if createdFrom != "" {
+		t, err := time.Parse(time.RFC3339, createdFrom)
+		if err != nil {
+			return domain.TaskFilter{}, err
+		}
+		filter.CreatedAfter = &t
+	}
+
+	if createdTo != "" {
+		t, err := time.Parse(time.RFC3339, createdTo)
+		if err != nil {
+			return domain.TaskFilter{}, err
+		}
+		filter.CreatedBefore = &t
+	}
+
+	return filter, nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func formatId(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
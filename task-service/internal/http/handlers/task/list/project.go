@@ -0,0 +1,95 @@
+package list
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type ProjectRequest struct {
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type ProjectTaskLister interface {
+	TaskLister
+	GetProjectById(ownerId, id uuid.UUID) (domain.Project, error)
+}
+
+// @Summary List project tasks
+// @Description List tasks of a project. Accepts the same filters, sorting and pagination as GET /task.
+// @Tags Project
+// @Produce json
+// @Param id path string true "Project UUID"
+// @Param task_status query string false "Comma separated statuses (TODO, IN_PROGRESS, DONE)"
+// @Param priority query string false "Comma separated priorities (URGENT, HIGH, MEDIUM, LOW)"
+// @Param repeat_task query string false "Comma separated repeat types (DAILY, WEEKLY, MONTHLY, YEARLY, NEVER)"
+// @Param tags query string false "Comma separated tags"
+// @Param tag_mode query string false "Match tasks with all (default) or any of the tags" Enums(all, any)
+// @Param created_from query string false "Created at or after (RFC3339)"
+// @Param created_to query string false "Created before (RFC3339)"
+// @Param title query string false "Title substring"
+// @Param sort query string false "Sort column, priority then due date by default" Enums(id, title, description, task_status, priority, created_at, repeat_task, start_at, due_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param limit query int false "Page size (max 200)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Security BearerAuth
+// @Success 200 {object} Response "Tasks retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Project not found"
+// @Failure 500 {object} response.Response "Failed to list tasks"
+// @Router /project/{id}/tasks [get]
+func NewForProject(log *slog.Logger, taskLister ProjectTaskLister) http.HandlerFunc {
+	listTasks := New(log, taskLister)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.list.NewForProject"
+
+		log := log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := ProjectRequest{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		_, err := taskLister.GetProjectById(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Info("Project not found", slog.String("ProjectId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Project not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get project", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list tasks"))
+			return
+		}
+
+		query := r.URL.Query()
+		query.Set("project_id", req.Id)
+		r.URL.RawQuery = query.Encode()
+
+		listTasks(w, r)
+	}
+}
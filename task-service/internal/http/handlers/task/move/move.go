@@ -0,0 +1,122 @@
+package move
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// Target project, null takes the task out of its project
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	ProjectId *string `json:"project_id"`
+}
+
+type Response struct {
+	response.Response
+	Id        string `json:"id"`
+	ProjectId string `json:"project_id,omitempty"`
+}
+
+type TaskMover interface {
+	MoveTask(ctx context.Context, ownerId, id uuid.UUID, projectId *uuid.UUID) error
+}
+
+// @Summary Move task to project
+// @Description Move a task to another project, or out of any project when project_id is null
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 200 {object} Response "Task moved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task or project not found"
+// @Failure 500 {object} response.Response "Failed to move task"
+// @Router /task/{id}/move [post]
+func New(log *slog.Logger, taskMover TaskMover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.move.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		var projectId *uuid.UUID
+		if req.ProjectId != nil {
+			id, err := uuid.Parse(*req.ProjectId)
+			if err != nil {
+				log.Error("Invalid project id", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			projectId = &id
+		}
+
+		err := taskMover.MoveTask(r.Context(), auth.UserId(r.Context()), uuid.MustParse(req.Id), projectId)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Info("Project not found", slog.String("ProjectId", *req.ProjectId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Project not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to move task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to move task"))
+			return
+		}
+
+		log.Info("Task moved", slog.String("TaskId", req.Id))
+
+		resp := Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		}
+		if projectId != nil {
+			resp.ProjectId = projectId.String()
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
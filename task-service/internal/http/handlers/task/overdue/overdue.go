@@ -0,0 +1,117 @@
+package overdue
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+const defaultLimit = 50
+
+// swagger:model
+type Request struct {
+	// example: 50
+	Limit int `json:"limit" validate:"min=1,max=200"`
+}
+
+type Task struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	TaskStatus  string `json:"task_status"`
+	CreatedAt   string `json:"created_at"`
+	RepeatTask  string `json:"repeat_task"`
+	StartAt     string `json:"start_at,omitempty"`
+	DueAt       string `json:"due_at"`
+}
+
+type Response struct {
+	response.Response
+	Tasks []Task `json:"tasks"`
+}
+
+type OverdueGetter interface {
+	GetOverdueTasks(ownerId uuid.UUID, now time.Time, limit int) ([]domain.Task, error)
+}
+
+// @Summary Get overdue tasks
+// @Description Get unfinished tasks past their due date, the most overdue first
+// @Tags Task
+// @Produce json
+// @Param limit query int false "Maximum number of tasks (max 200)"
+// @Security BearerAuth
+// @Success 200 {object} Response "Overdue tasks retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to get overdue tasks"
+// @Router /task/overdue [get]
+func New(log *slog.Logger, overdueGetter OverdueGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.overdue.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Limit: defaultLimit,
+		}
+
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				log.Error("Invalid limit", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			req.Limit = n
+		}
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		found, err := overdueGetter.GetOverdueTasks(auth.UserId(r.Context()), time.Now(), req.Limit)
+		if err != nil {
+			log.Error("Failed to get overdue tasks", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get overdue tasks"))
+			return
+		}
+
+		tasks := make([]Task, 0, len(found))
+		for _, task := range found {
+			t := Task{
+				Id:          task.Id.String(),
+				Title:       task.Title,
+				Description: task.Description,
+				TaskStatus:  string(task.TaskStatus),
+				CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
+				RepeatTask:  string(task.RepeatTask),
+				DueAt:       task.DueAt.Format("2006-01-02 15:04:05"),
+			}
+			if task.StartAt != nil {
+				t.StartAt = task.StartAt.Format("2006-01-02 15:04:05")
+			}
+			tasks = append(tasks, t)
+		}
+
+		log.Info("Overdue tasks get", slog.Int("count", len(tasks)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Tasks:    tasks,
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package parent
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// New parent task, null makes the task a top level one
+	// example: 5d2b7e0c-1f4a-4c39-8e6d-0a9b3c7f2e18
+	ParentId *string `json:"parent_id"`
+}
+
+type Response struct {
+	response.Response
+	Id       string `json:"id"`
+	ParentId string `json:"parent_id,omitempty"`
+}
+
+type ParentSetter interface {
+	SetTaskParent(ctx context.Context, ownerId, id uuid.UUID, parentId *uuid.UUID) error
+}
+
+// @Summary Set parent task
+// @Description Make a task a subtask of another one, or a top level task when parent_id is null
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 200 {object} Response "Parent set successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task or parent task not found"
+// @Failure 409 {object} response.Response "Parent is a subtask of the task"
+// @Failure 500 {object} response.Response "Failed to set parent"
+// @Router /task/{id}/parent [post]
+func New(log *slog.Logger, parentSetter ParentSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.parent.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		var parentId *uuid.UUID
+		if req.ParentId != nil {
+			id, err := uuid.Parse(*req.ParentId)
+			if err != nil {
+				log.Error("Invalid parent id", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			parentId = &id
+		}
+
+		err := parentSetter.SetTaskParent(r.Context(), auth.UserId(r.Context()), uuid.MustParse(req.Id), parentId)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrParentNotFound) {
+			log.Info("Parent task not found", slog.String("ParentId", *req.ParentId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Parent task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrTaskCycle) {
+			log.Error("Parent rejected", sl.Error(err))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict("Task cannot be a subtask of itself"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to set parent", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to set parent"))
+			return
+		}
+
+		log.Info("Parent set", slog.String("TaskId", req.Id))
+
+		resp := Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		}
+		if parentId != nil {
+			resp.ParentId = parentId.String()
+		}
+
+		render.JSON(w, r, resp)
+	}
+}
@@ -0,0 +1,95 @@
+package reopen
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/workflow"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id"`
+}
+
+type TaskReopener interface {
+	UpdateTaskById(ctx context.Context, ownerId, id uuid.UUID, updates domain.Task, check domain.StatusCheck) error
+}
+
+// @Summary Reopen task
+// @Description Move a DONE task back to TODO
+// @Tags Task
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Task reopened successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found"
+// @Failure 409 {object} response.Response "Task is not done"
+// @Failure 500 {object} response.Response "Failed to reopen task"
+// @Router /task/{id}/reopen [post]
+func New(log *slog.Logger, taskReopener TaskReopener) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.reopen.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		err := taskReopener.UpdateTaskById(r.Context(), auth.UserId(r.Context()), uuid.MustParse(req.Id), domain.Task{TaskStatus: domain.TODO}, workflow.Reopen)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrTaskNotDone) {
+			log.Error("Task is not done", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusConflict)
+			render.JSON(w, r, response.ErrorConflict("Only DONE tasks can be reopened"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to reopen task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to reopen task"))
+			return
+		}
+
+		log.Info("Task reopened", slog.String("TaskId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id"`
+
+	// example: 3
+	Restored int `json:"restored"`
+}
+
+type TaskRestorer interface {
+	RestoreTask(ctx context.Context, ownerId, id uuid.UUID) ([]uuid.UUID, error)
+}
+
+// @Summary Restore deleted task
+// @Description Take a task out of the trash together with the subtasks deleted with it. If its parent is still in the trash, the task becomes a top level task.
+// @Tags Task
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Task restored successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found in trash"
+// @Failure 500 {object} response.Response "Failed to restore task"
+// @Router /task/{id}/restore [post]
+func New(log *slog.Logger, taskRestorer TaskRestorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.restore.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		restored, err := taskRestorer.RestoreTask(r.Context(), auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found in trash", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found in trash"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to restore task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to restore task"))
+			return
+		}
+
+		log.Info("Task restored", slog.String("TaskId", req.Id), slog.Int("restored", len(restored)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+			Restored: len(restored),
+		})
+	}
+}
@@ -1,132 +1,206 @@
-package save
-
-import (
-	"encoding/json"
-	"log/slog"
-	"net/http"
-	"task-service/domain"
-	"task-service/internal/http/handlers/validators"
-	"task-service/internal/lib/api/response"
-	"task-service/internal/lib/logger/sl"
-	"task-service/internal/repo/redis"
-	"time"
-
-	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/render"
-	"github.com/go-playground/validator"
-	"github.com/google/uuid"
-)
-
-// swagger:model
-type Request struct {
-	// example: Sample Task
-	Title string `json:"title,omitempty"`
-
-	// example: This is a sample task description.
-	Description string `json:"description,omitempty"`
-
-	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
-	// example: DAILY
-	RepeatTask string `json:"repeat_task,omitempty" validate:"repeat_task_valid"`
-}
-
-type Response struct {
-	response.Response
-
-	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
-	TaskId string `json:"task_id,omitempty"`
-}
-
-type TaskSaver interface {
-	SaveTask(entity domain.Task) error
-}
-
-// @Summary Create task
-// @Description Create and save task
-// @Tags Task
-// @Accept json
-// @Produce json
-// @Param request body Request true "Request"
-// @Success 201 {object} Response "Task created successfully"
-// @Failure 400 {object} response.Response "Invalid request"
-// @Failure 500 {object} response.Response "Failed to save task"
-// @Router /task [post]
-func New(log *slog.Logger, taskSaver TaskSaver, rdb *redis.RedisDB) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.task.save.New"
-
-		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
-
-		var req Request
-
-		err := render.DecodeJSON(r.Body, &req)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			log.Error("Failed to decode request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
-			return
-		}
-
-		log.Info("Request decoded to JSON", slog.Any("request", req))
-
-		validate := validator.New()
-		validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
-
-		if err := validate.Struct(req); err != nil {
-			log.Error("Invalid request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Invalid request"))
-			return
-		}
-
-		task, err := CreateTask(req)
-		if err != nil {
-			log.Error("Invalid request", sl.Error(err))
-			render.JSON(w, r, response.ErrorClient("Invalid request"))
-			return
-		}
-
-		err = taskSaver.SaveTask(task)
-		if err != nil {
-			log.Error("Failed to save task", sl.Error(err))
-			render.JSON(w, r, response.Error("Failed to save task"))
-			return
-		}
-
-		ctx := r.Context()
-		taskJSON, err := json.Marshal(task)
-		if err != nil {
-			log.Error("Failed to marshal task", sl.Error(err))
-		} else {
-			if err := rdb.Set(ctx, task.Id.String(), string(taskJSON), 5*time.Minute); err != nil {
-				log.Error("Failed to set task in Redis", sl.Error(err))
-			} else {
-				log.Info("Task cached in Redis", slog.String("TaskId", task.Id.String()))
-			}
-		}
-
-		log.Info("Task created successfully", slog.String("TaskId", task.Id.String()))
-
-		render.JSON(w, r, Response{
-			Response: response.StatusCreated(),
-			TaskId:   task.Id.String(),
-		})
-		w.WriteHeader(http.StatusCreated)
-	}
-}
-
-func CreateTask(req Request) (domain.Task, error) {
-	if req.RepeatTask == "" {
-		req.RepeatTask = "NEVER"
-	}
-
-	task := domain.Task{
-		Id:          uuid.New(),
-		Title:       req.Title,
-		Description: req.Description,
-		TaskStatus:  domain.TODO,
-		CreatedAt:   time.Now(),
-		RepeatTask:  domain.TaskRepeatType(req.RepeatTask),
-	}
-
-	return task, nil
-}
+package save
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: Sample Task
+	Title string `json:"title,omitempty"`
+
+	// example: This is a sample task description.
+	Description string `json:"description,omitempty"`
+
+	// enum: DAILY, WEEKLY, MONTHLY, YEARLY, NEVER
+	// example: DAILY
+	RepeatTask string `json:"repeat_task,omitempty" validate:"repeat_task_valid"`
+
+	// enum: URGENT, HIGH, MEDIUM, LOW
+	// example: HIGH
+	Priority string `json:"priority,omitempty" validate:"priority_valid"`
+
+	// example: 7c0f5a2e-3b8d-4f57-9a41-2d6e8c1b0f93
+	ProjectId string `json:"project_id,omitempty" validate:"omitempty,id_valid"`
+
+	// example: 5d2b7e0c-1f4a-4c39-8e6d-0a9b3c7f2e18
+	ParentId string `json:"parent_id,omitempty" validate:"omitempty,id_valid"`
+
+	// example: ["backend", "urgent"]
+	Tags []string `json:"tags,omitempty" validate:"max=20,dive,required,max=64"`
+
+	// example: 2025-04-21T09:00:00Z
+	StartAt *time.Time `json:"start_at,omitempty"`
+
+	// example: 2025-04-25T18:00:00Z
+	DueAt *time.Time `json:"due_at,omitempty"`
+
+	// IANA time zone the series repeats in, the recurrence.timezone setting by default
+	// example: Europe/Berlin
+	Timezone string `json:"timezone,omitempty" validate:"timezone_valid"`
+}
+
+type Response struct {
+	response.Response
+
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	TaskId string `json:"task_id,omitempty"`
+}
+
+type TaskSaver interface {
+	SaveTask(ctx context.Context, entity domain.Task) error
+}
+
+// @Summary Create task
+// @Description Create and save task
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 201 {object} Response "Task created successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Project or parent task not found"
+// @Failure 500 {object} response.Response "Failed to save task"
+// @Router /task [post]
+func New(log *slog.Logger, taskSaver TaskSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.save.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		log.Info("Request decoded to JSON", slog.Any("request", req))
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+		validate.RegisterValidation("repeat_task_valid", validators.IsValidRepeatTask)
+		validate.RegisterValidation("timezone_valid", validators.IsValidTimezone)
+		validate.RegisterValidation("priority_valid", validators.IsValidPriority)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		task, err := CreateTask(req, auth.UserId(r.Context()))
+		if err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		err = taskSaver.SaveTask(r.Context(), task)
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Info("Project not found", slog.String("ProjectId", req.ProjectId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Project not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrParentNotFound) {
+			log.Info("Parent task not found", slog.String("ParentId", req.ParentId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Parent task not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to save task", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to save task"))
+			return
+		}
+
+		log.Info("Task created successfully", slog.String("TaskId", task.Id.String()))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusCreated(),
+			TaskId:   task.Id.String(),
+		})
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func CreateTask(req Request, ownerId uuid.UUID) (domain.Task, error) {
+	if req.RepeatTask == "" {
+		req.RepeatTask = "NEVER"
+	}
+
+	if req.Priority == "" {
+		req.Priority = string(domain.MEDIUM)
+	}
+
+	if req.StartAt != nil && req.DueAt != nil && req.StartAt.After(*req.DueAt) {
+		return domain.Task{}, domain.ErrStartAfterDue
+	}
+
+	now := time.Now().UTC()
+	id := uuid.New()
+
+	task := domain.Task{
+		Id:          id,
+		Title:       req.Title,
+		Description: req.Description,
+		TaskStatus:  domain.TODO,
+		Priority:    domain.TaskPriority(req.Priority),
+		CreatedAt:   now,
+		RepeatTask:  domain.TaskRepeatType(req.RepeatTask),
+		SeriesId:    id,
+		SeriesStart: now,
+		Timezone:    req.Timezone,
+		StartAt:     utc(req.StartAt),
+		DueAt:       utc(req.DueAt),
+		OwnerId:     ownerId,
+		Tags:        domain.NormalizeTags(req.Tags),
+		Version:     1,
+	}
+
+	if req.ProjectId != "" {
+		projectId, err := uuid.Parse(req.ProjectId)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		task.ProjectId = &projectId
+	}
+
+	if req.ParentId != "" {
+		parentId, err := uuid.Parse(req.ParentId)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		task.ParentId = &parentId
+	}
+
+	return task, nil
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
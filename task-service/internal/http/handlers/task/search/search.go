@@ -0,0 +1,120 @@
+package search
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+const defaultLimit = 20
+
+// swagger:model
+type Request struct {
+	// example: release notes
+	Query string `json:"q" validate:"required,max=256"`
+
+	// example: 20
+	Limit int `json:"limit" validate:"min=1,max=100"`
+}
+
+type Result struct {
+	Id                   string  `json:"id"`
+	Title                string  `json:"title"`
+	Description          string  `json:"description"`
+	TaskStatus           string  `json:"task_status"`
+	CreatedAt            string  `json:"created_at"`
+	RepeatTask           string  `json:"repeat_task"`
+	Rank                 float64 `json:"rank"`
+	TitleHighlight       string  `json:"title_highlight"`
+	DescriptionHighlight string  `json:"description_highlight"`
+}
+
+type Response struct {
+	response.Response
+	Results []Result `json:"results"`
+}
+
+type TaskSearcher interface {
+	SearchTasks(ownerId uuid.UUID, text string, limit int) ([]domain.SearchResult, error)
+}
+
+// @Summary Search tasks
+// @Description Full-text search over task title and description
+// @Tags Task
+// @Produce json
+// @Param q query string true "Search query (websearch syntax: words, \"phrases\", -exclusions, OR)"
+// @Param limit query int false "Maximum number of results (max 100)"
+// @Security BearerAuth
+// @Success 200 {object} Response "Tasks found successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to search tasks"
+// @Router /task/search [get]
+func New(log *slog.Logger, taskSearcher TaskSearcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.search.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Query: r.URL.Query().Get("q"),
+			Limit: defaultLimit,
+		}
+
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				log.Error("Invalid limit", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			req.Limit = n
+		}
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		found, err := taskSearcher.SearchTasks(auth.UserId(r.Context()), req.Query, req.Limit)
+		if err != nil {
+			log.Error("Failed to search tasks", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to search tasks"))
+			return
+		}
+
+		results := make([]Result, 0, len(found))
+		for _, res := range found {
+			results = append(results, Result{
+				Id:                   res.Task.Id.String(),
+				Title:                res.Task.Title,
+				Description:          res.Task.Description,
+				TaskStatus:           string(res.Task.TaskStatus),
+				CreatedAt:            res.Task.CreatedAt.Format("2006-01-02 15:04:05"),
+				RepeatTask:           string(res.Task.RepeatTask),
+				Rank:                 res.Rank,
+				TitleHighlight:       res.TitleHighlight,
+				DescriptionHighlight: res.DescriptionHighlight,
+			})
+		}
+
+		log.Info("Tasks searched", slog.Int("count", len(results)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Results:  results,
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package subtree
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Node struct {
+	Id          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	TaskStatus  string    `json:"task_status"`
+	CreatedAt   string    `json:"created_at"`
+	RepeatTask  string    `json:"repeat_task"`
+	StartAt     string    `json:"start_at,omitempty"`
+	DueAt       string    `json:"due_at,omitempty"`
+	Overdue     bool      `json:"overdue"`
+	Progress    *Progress `json:"progress,omitempty"`
+	Children    []*Node   `json:"children"`
+}
+
+type Progress struct {
+	Total   int `json:"total"`
+	Done    int `json:"done"`
+	Percent int `json:"percent"`
+}
+
+type Response struct {
+	response.Response
+	Task *Node `json:"task"`
+}
+
+type SubtreeGetter interface {
+	GetTaskSubtree(ownerId, id uuid.UUID) ([]domain.Task, error)
+}
+
+// @Summary Get task subtree
+// @Description Get a task with all of its subtasks at every depth as a tree. Every node has the progress of its own subtasks.
+// @Tags Task
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Subtree retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Task not found"
+// @Failure 500 {object} response.Response "Failed to get subtree"
+// @Router /task/{id}/subtree [get]
+func New(log *slog.Logger, subtreeGetter SubtreeGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.subtree.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		tasks, err := subtreeGetter.GetTaskSubtree(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Info("Task not found", slog.String("TaskId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Task not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get subtree", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get subtree"))
+			return
+		}
+
+		log.Info("Subtree get", slog.String("TaskId", req.Id), slog.Int("count", len(tasks)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Task:     BuildTree(tasks, time.Now()),
+		})
+	}
+}
+
+// BuildTree nests tasks under their parents. tasks must start with the root
+// and list every parent before its children, as GetTaskSubtree does.
+func BuildTree(tasks []domain.Task, now time.Time) *Node {
+	nodes := make([]*Node, len(tasks))
+	progress := make([]domain.Progress, len(tasks))
+	index := make(map[uuid.UUID]int, len(tasks))
+	parents := make([]int, len(tasks))
+
+	for i, task := range tasks {
+		index[task.Id] = i
+		parents[i] = -1
+		if task.ParentId != nil {
+			if p, ok := index[*task.ParentId]; ok && i > 0 {
+				parents[i] = p
+			}
+		}
+
+		nodes[i] = &Node{
+			Id:          task.Id.String(),
+			Title:       task.Title,
+			Description: task.Description,
+			TaskStatus:  string(task.TaskStatus),
+			CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
+			RepeatTask:  string(task.RepeatTask),
+			StartAt:     response.FormatTime(task.StartAt),
+			DueAt:       response.FormatTime(task.DueAt),
+			Overdue:     task.IsOverdue(now),
+			Children:    []*Node{},
+		}
+	}
+
+	// Children come after their parents, so walking backwards sees every
+	// subtree complete before its parent is reached.
+	for i := len(tasks) - 1; i > 0; i-- {
+		p := parents[i]
+		if p < 0 {
+			continue
+		}
+
+		progress[p].Total += progress[i].Total + 1
+		progress[p].Done += progress[i].Done
+		if tasks[i].TaskStatus == domain.DONE {
+			progress[p].Done++
+		}
+	}
+
+	for i, node := range nodes {
+		if p := parents[i]; p >= 0 {
+			nodes[p].Children = append(nodes[p].Children, node)
+		}
+		if progress[i].Total > 0 {
+			node.Progress = &Progress{
+				Total:   progress[i].Total,
+				Done:    progress[i].Done,
+				Percent: progress[i].Percent(),
+			}
+		}
+	}
+
+	return nodes[0]
+}
@@ -0,0 +1,125 @@
+package trash
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+const defaultLimit = 50
+
+// swagger:model
+type Request struct {
+	// example: 50
+	Limit int `json:"limit" validate:"min=1,max=200"`
+}
+
+type Task struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	TaskStatus  string `json:"task_status"`
+	Priority    string `json:"priority"`
+	CreatedAt   string `json:"created_at"`
+	ProjectId   string `json:"project_id,omitempty"`
+	ParentId    string `json:"parent_id,omitempty"`
+	DeletedAt   string `json:"deleted_at"`
+
+	// When the task is removed for good
+	PurgeAt string `json:"purge_at"`
+}
+
+type Response struct {
+	response.Response
+	Tasks []Task `json:"tasks"`
+}
+
+type TrashLister interface {
+	ListTrash(ownerId uuid.UUID, limit int) ([]domain.Task, error)
+}
+
+// @Summary List deleted tasks
+// @Description List tasks in the trash, the most recently deleted first. They can be restored until they are purged.
+// @Tags Task
+// @Produce json
+// @Param limit query int false "Maximum number of tasks (max 200)"
+// @Security BearerAuth
+// @Success 200 {object} Response "Deleted tasks retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list deleted tasks"
+// @Router /task/trash [get]
+func New(log *slog.Logger, trashLister TrashLister, retention time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.trash.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Limit: defaultLimit,
+		}
+
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				log.Error("Invalid limit", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			req.Limit = n
+		}
+
+		validate := validator.New()
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		found, err := trashLister.ListTrash(auth.UserId(r.Context()), req.Limit)
+		if err != nil {
+			log.Error("Failed to list deleted tasks", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list deleted tasks"))
+			return
+		}
+
+		tasks := make([]Task, 0, len(found))
+		for _, task := range found {
+			t := Task{
+				Id:          task.Id.String(),
+				Title:       task.Title,
+				Description: task.Description,
+				TaskStatus:  string(task.TaskStatus),
+				Priority:    string(task.Priority),
+				CreatedAt:   task.CreatedAt.Format("2006-01-02 15:04:05"),
+				DeletedAt:   task.DeletedAt.Format("2006-01-02 15:04:05"),
+				PurgeAt:     task.DeletedAt.Add(retention).Format("2006-01-02 15:04:05"),
+			}
+			if task.ProjectId != nil {
+				t.ProjectId = task.ProjectId.String()
+			}
+			if task.ParentId != nil {
+				t.ParentId = task.ParentId.String()
+			}
+			tasks = append(tasks, t)
+		}
+
+		log.Info("Deleted tasks listed", slog.Int("count", len(tasks)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Tasks:    tasks,
+		})
+	}
+}
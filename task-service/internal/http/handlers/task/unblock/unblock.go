@@ -0,0 +1,93 @@
+package unblock
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: b063de04-6fd7-41cd-8f4c-8d113e786be8
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: 9a4e1c6b-2d3f-4e58-b7a0-1c2d3e4f5a6b
+	BlockerId string `json:"blocker_id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id        string `json:"id"`
+	BlockerId string `json:"blocker_id"`
+}
+
+type BlockerRemover interface {
+	RemoveBlocker(ownerId, id, blockerId uuid.UUID) error
+}
+
+// @Summary Remove blocking task
+// @Description Remove a dependency between two tasks
+// @Tags Task
+// @Produce json
+// @Param id path string true "Task UUID"
+// @Param blocker_id path string true "Blocking task UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Blocker removed successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Dependency not found"
+// @Failure 500 {object} response.Response "Failed to remove blocker"
+// @Router /task/{id}/blockers/{blocker_id} [delete]
+func New(log *slog.Logger, blockerRemover BlockerRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.task.unblock.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id:        chi.URLParam(r, "id"),
+			BlockerId: chi.URLParam(r, "blocker_id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		err := blockerRemover.RemoveBlocker(auth.UserId(r.Context()), uuid.MustParse(req.Id), uuid.MustParse(req.BlockerId))
+		if errors.Is(err, domain.ErrDependencyNotFound) {
+			log.Info("Dependency not found", slog.String("TaskId", req.Id), slog.String("BlockerId", req.BlockerId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Dependency not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to remove blocker", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to remove blocker"))
+			return
+		}
+
+		log.Info("Blocker removed", slog.String("TaskId", req.Id), slog.String("BlockerId", req.BlockerId))
+
+		render.JSON(w, r, Response{
+			Response:  response.StatusOK(),
+			Id:        req.Id,
+			BlockerId: req.BlockerId,
+		})
+	}
+}
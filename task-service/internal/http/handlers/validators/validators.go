@@ -1,35 +1,97 @@
-package validators
-
-import (
-	"github.com/go-playground/validator"
-	"github.com/google/uuid"
-)
-
-func IsValidRepeatTask(fl validator.FieldLevel) bool {
-	repeat := fl.Field().String()
-	switch repeat {
-	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY", "NEVER", "":
-		return true
-	default:
-		return false
-	}
-}
-
-func IsValidId(fl validator.FieldLevel) bool {
-	id := fl.Field().String()
-	_, err := uuid.Parse(id)
-	if err != nil {
-		return false
-	}
-	return true
-}
-
-func IsValidTaskStatus(fl validator.FieldLevel) bool {
-	status := fl.Field().String()
-	switch status {
-	case "TODO", "IN_PROGRESS", "DONE":
-		return true
-	default:
-		return false
-	}
-}
+package validators
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+	"task-service/internal/lib/webhook"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+func IsValidRepeatTask(fl validator.FieldLevel) bool {
+	repeat := fl.Field().String()
+	switch repeat {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY", "NEVER", "":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidTimezone accepts IANA time zone names such as "Europe/Berlin", and
+// the empty string. "Local" is rejected: it depends on the server.
+func IsValidTimezone(fl validator.FieldLevel) bool {
+	name := fl.Field().String()
+	if name == "" {
+		return true
+	}
+	if name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+func IsValidId(fl validator.FieldLevel) bool {
+	id := fl.Field().String()
+	_, err := uuid.Parse(id)
+	if err != nil {
+		return false
+	}
+	return true
+}
+
+func IsValidPriority(fl validator.FieldLevel) bool {
+	priority := fl.Field().String()
+	switch priority {
+	case "URGENT", "HIGH", "MEDIUM", "LOW", "":
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidTaskStatus(fl validator.FieldLevel) bool {
+	status := fl.Field().String()
+	switch status {
+	case "TODO", "IN_PROGRESS", "DONE":
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidEventType(fl validator.FieldLevel) bool {
+	event := fl.Field().String()
+	switch event {
+	case "TaskCreated", "TaskUpdated", "TaskDeleted":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidWebhookURL also rejects hosts that are not on the public internet
+// when the URL names them directly. Names that resolve to such addresses
+// are rejected by the delivery client.
+func IsValidWebhookURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil {
+		return false
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return webhook.IsPublic(addr)
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package change
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 5a0e8f4c-1d2b-4c3a-9e8f-7b6a5c4d3e2f
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: https://ci.example.com/hooks/tasks
+	URL *string `json:"url,omitempty" validate:"omitempty,max=2048,webhook_url"`
+
+	// Events to deliver, [] for all events
+	// example: ["TaskUpdated"]
+	Events *[]string `json:"events,omitempty" validate:"omitempty,max=3,dive,event_valid"`
+
+	// example: 3f9a1c0e5b7d4e2f8a6c1b0d9e7f5a3c
+	Secret *string `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+
+	// Paused webhooks get no new deliveries
+	// example: false
+	Active *bool `json:"active,omitempty"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id"`
+}
+
+type WebhookChanger interface {
+	UpdateWebhookById(ownerId, id uuid.UUID, updates domain.WebhookUpdate) error
+}
+
+// @Summary Update webhook by uuid
+// @Description Change the URL, events, secret of a webhook or pause it. Omitted fields are kept.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook UUID"
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 200 {object} Response "Webhook updated successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Webhook not found"
+// @Failure 500 {object} response.Response "Failed to update webhook"
+// @Router /webhook/{id} [patch]
+func New(log *slog.Logger, webhookChanger WebhookChanger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.change.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+		validate.RegisterValidation("webhook_url", validators.IsValidWebhookURL)
+		validate.RegisterValidation("event_valid", validators.IsValidEventType)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		updates := domain.WebhookUpdate{
+			URL:    req.URL,
+			Secret: req.Secret,
+			Active: req.Active,
+		}
+		if req.Events != nil {
+			events := make([]domain.EventType, 0, len(*req.Events))
+			for _, event := range *req.Events {
+				events = append(events, domain.EventType(event))
+			}
+			updates.Events = &events
+		}
+
+		err := webhookChanger.UpdateWebhookById(auth.UserId(r.Context()), uuid.MustParse(req.Id), updates)
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Info("Webhook not found", slog.String("WebhookId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Webhook not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to update webhook", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to update webhook"))
+			return
+		}
+
+		log.Info("Webhook updated", slog.String("WebhookId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package delete
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 5a0e8f4c-1d2b-4c3a-9e8f-7b6a5c4d3e2f
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id string `json:"id"`
+}
+
+type webhookDeleter interface {
+	DeleteWebhookById(ownerId, id uuid.UUID) error
+}
+
+// @Summary Delete webhook by uuid
+// @Description Delete webhook by its UUID together with its pending deliveries and delivery log.
+// @Tags Webhook
+// @Produce json
+// @Param id path string true "Webhook UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Webhook deleted successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Webhook not found"
+// @Failure 500 {object} response.Response "Failed to delete webhook"
+// @Router /webhook/{id} [delete]
+func New(log *slog.Logger, webhookDeleter webhookDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.delete.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		err := webhookDeleter.DeleteWebhookById(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Info("Webhook not found", slog.String("WebhookId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Webhook not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to delete webhook", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to delete webhook"))
+			return
+		}
+
+		log.Info("Webhook deleted", slog.String("WebhookId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Id:       req.Id,
+		})
+	}
+}
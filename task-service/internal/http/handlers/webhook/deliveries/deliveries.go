@@ -0,0 +1,175 @@
+package deliveries
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 5a0e8f4c-1d2b-4c3a-9e8f-7b6a5c4d3e2f
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: dead
+	Status string `json:"status" validate:"omitempty,oneof=pending delivered dead"`
+
+	// example: 50
+	Limit int `json:"limit" validate:"min=0,max=200"`
+
+	Cursor string `json:"cursor"`
+}
+
+type Delivery struct {
+	// example: 17
+	Id int64 `json:"id"`
+
+	// Outbox id of the event, the same for every delivery of one event
+	// example: 42
+	EventId int64 `json:"event_id"`
+
+	// example: TaskUpdated
+	EventType string `json:"event_type"`
+
+	Payload json.RawMessage `json:"payload" swaggertype:"object"`
+
+	// enum: pending, delivered, dead
+	Status string `json:"status"`
+
+	// example: 3
+	Attempts int `json:"attempts"`
+
+	NextAttemptAt string `json:"next_attempt_at,omitempty"`
+
+	// Status of the last response, 0 if none was received
+	// example: 503
+	LastStatusCode int    `json:"last_status_code"`
+	LastError      string `json:"last_error,omitempty"`
+	CreatedAt      string `json:"created_at"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+}
+
+type Response struct {
+	response.Response
+	Deliveries []Delivery `json:"deliveries"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type DeliveryLister interface {
+	ListDeliveries(filter domain.DeliveryFilter) (domain.DeliveryPage, error)
+}
+
+// @Summary List webhook deliveries
+// @Description Delivery log of a webhook, newest first. Dead deliveries failed every attempt and can be retried.
+// @Tags Webhook
+// @Produce json
+// @Param id path string true "Webhook UUID"
+// @Param status query string false "Only deliveries in this status" Enums(pending, delivered, dead)
+// @Param limit query int false "Page size (max 200)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Security BearerAuth
+// @Success 200 {object} Response "Deliveries retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Webhook not found"
+// @Failure 500 {object} response.Response "Failed to list deliveries"
+// @Router /webhook/{id}/deliveries [get]
+func New(log *slog.Logger, deliveryLister DeliveryLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.deliveries.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id:     chi.URLParam(r, "id"),
+			Status: r.URL.Query().Get("status"),
+			Cursor: r.URL.Query().Get("cursor"),
+		}
+
+		if limit := r.URL.Query().Get("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				log.Error("Invalid limit", sl.Error(err))
+				render.JSON(w, r, response.ErrorClient("Invalid request"))
+				return
+			}
+			req.Limit = n
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		page, err := deliveryLister.ListDeliveries(domain.DeliveryFilter{
+			OwnerId:   auth.UserId(r.Context()),
+			WebhookId: uuid.MustParse(req.Id),
+			Status:    domain.DeliveryStatus(req.Status),
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+		})
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Info("Webhook not found", slog.String("WebhookId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Webhook not found"))
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			log.Error("Invalid cursor", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid cursor"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to list deliveries", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list deliveries"))
+			return
+		}
+
+		deliveries := make([]Delivery, 0, len(page.Deliveries))
+		for _, delivery := range page.Deliveries {
+			item := Delivery{
+				Id:             delivery.Id,
+				EventId:        delivery.EventId,
+				EventType:      string(delivery.EventType),
+				Payload:        delivery.Payload,
+				Status:         string(delivery.Status),
+				Attempts:       delivery.Attempts,
+				LastStatusCode: delivery.LastStatusCode,
+				LastError:      delivery.LastError,
+				CreatedAt:      delivery.CreatedAt.Format("2006-01-02 15:04:05"),
+			}
+			if delivery.NextAttemptAt != nil {
+				item.NextAttemptAt = delivery.NextAttemptAt.Format("2006-01-02 15:04:05")
+			}
+			if delivery.DeliveredAt != nil {
+				item.DeliveredAt = delivery.DeliveredAt.Format("2006-01-02 15:04:05")
+			}
+			deliveries = append(deliveries, item)
+		}
+
+		log.Info("Deliveries listed", slog.Int("count", len(deliveries)))
+
+		render.JSON(w, r, Response{
+			Response:   response.StatusOK(),
+			Deliveries: deliveries,
+			NextCursor: page.NextCursor,
+		})
+	}
+}
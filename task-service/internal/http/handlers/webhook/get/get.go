@@ -0,0 +1,99 @@
+package get
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 5a0e8f4c-1d2b-4c3a-9e8f-7b6a5c4d3e2f
+	Id string `json:"id" validate:"id_valid,required"`
+}
+
+type Response struct {
+	response.Response
+	Id        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type WebhookGetter interface {
+	GetWebhookById(ownerId, id uuid.UUID) (domain.Webhook, error)
+}
+
+// @Summary Get webhook by uuid
+// @Description Get webhook by its UUID. The secret is not returned.
+// @Tags Webhook
+// @Produce json
+// @Param id path string true "Webhook UUID"
+// @Security BearerAuth
+// @Success 200 {object} Response "Webhook retrieved successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Webhook not found"
+// @Failure 500 {object} response.Response "Failed to get webhook"
+// @Router /webhook/{id} [get]
+func New(log *slog.Logger, webhookGetter WebhookGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.get.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		webhook, err := webhookGetter.GetWebhookById(auth.UserId(r.Context()), uuid.MustParse(req.Id))
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Info("Webhook not found", slog.String("WebhookId", req.Id))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Webhook not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to get webhook", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to get webhook"))
+			return
+		}
+
+		events := make([]string, 0, len(webhook.Events))
+		for _, event := range webhook.Events {
+			events = append(events, string(event))
+		}
+
+		log.Info("Webhook get", slog.String("WebhookId", req.Id))
+
+		render.JSON(w, r, Response{
+			Response:  response.StatusOK(),
+			Id:        webhook.Id.String(),
+			URL:       webhook.URL,
+			Events:    events,
+			Active:    webhook.Active,
+			CreatedAt: webhook.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package list
+
+import (
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// Webhook is a subscription without its secret.
+type Webhook struct {
+	Id        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	Active    bool     `json:"active"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type Response struct {
+	response.Response
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+type WebhookLister interface {
+	ListWebhooks(ownerId uuid.UUID) ([]domain.Webhook, error)
+}
+
+// @Summary List webhooks
+// @Description List webhooks of the user, oldest first. Secrets are not returned.
+// @Tags Webhook
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} Response "Webhooks retrieved successfully"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to list webhooks"
+// @Router /webhook [get]
+func New(log *slog.Logger, webhookLister WebhookLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.list.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		found, err := webhookLister.ListWebhooks(auth.UserId(r.Context()))
+		if err != nil {
+			log.Error("Failed to list webhooks", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to list webhooks"))
+			return
+		}
+
+		webhooks := make([]Webhook, 0, len(found))
+		for _, webhook := range found {
+			webhooks = append(webhooks, fromDomain(webhook))
+		}
+
+		log.Info("Webhooks listed", slog.Int("count", len(webhooks)))
+
+		render.JSON(w, r, Response{
+			Response: response.StatusOK(),
+			Webhooks: webhooks,
+		})
+	}
+}
+
+func fromDomain(webhook domain.Webhook) Webhook {
+	events := make([]string, 0, len(webhook.Events))
+	for _, event := range webhook.Events {
+		events = append(events, string(event))
+	}
+
+	return Webhook{
+		Id:        webhook.Id.String(),
+		URL:       webhook.URL,
+		Events:    events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,101 @@
+package retry
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: 5a0e8f4c-1d2b-4c3a-9e8f-7b6a5c4d3e2f
+	Id string `json:"id" validate:"id_valid,required"`
+
+	// example: 17
+	DeliveryId int64 `json:"delivery_id" validate:"required,min=1"`
+}
+
+type Response struct {
+	response.Response
+
+	// example: 17
+	DeliveryId int64 `json:"delivery_id"`
+}
+
+type DeliveryRetrier interface {
+	RetryDelivery(ownerId, webhookId uuid.UUID, id int64) error
+}
+
+// @Summary Retry webhook delivery
+// @Description Send a delivery again with a fresh set of attempts, typically a dead one.
+// @Tags Webhook
+// @Produce json
+// @Param id path string true "Webhook UUID"
+// @Param delivery_id path int true "Delivery id"
+// @Security BearerAuth
+// @Success 200 {object} Response "Delivery scheduled"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 404 {object} response.Response "Delivery not found"
+// @Failure 500 {object} response.Response "Failed to retry delivery"
+// @Router /webhook/{id}/deliveries/{delivery_id}/retry [post]
+func New(log *slog.Logger, deliveryRetrier DeliveryRetrier) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.retry.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		req := Request{
+			Id: chi.URLParam(r, "id"),
+		}
+
+		deliveryId, err := strconv.ParseInt(chi.URLParam(r, "delivery_id"), 10, 64)
+		if err != nil {
+			log.Error("Invalid delivery id", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+		req.DeliveryId = deliveryId
+
+		validate := validator.New()
+		validate.RegisterValidation("id_valid", validators.IsValidId)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		err = deliveryRetrier.RetryDelivery(auth.UserId(r.Context()), uuid.MustParse(req.Id), req.DeliveryId)
+		if errors.Is(err, domain.ErrDeliveryNotFound) {
+			log.Info("Delivery not found", slog.Int64("DeliveryId", req.DeliveryId))
+			render.Status(r, http.StatusNotFound)
+			render.JSON(w, r, response.ErrorNotFound("Delivery not found"))
+			return
+		}
+		if err != nil {
+			log.Error("Failed to retry delivery", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to retry delivery"))
+			return
+		}
+
+		log.Info("Delivery scheduled", slog.Int64("DeliveryId", req.DeliveryId))
+
+		render.JSON(w, r, Response{
+			Response:   response.StatusOK(),
+			DeliveryId: req.DeliveryId,
+		})
+	}
+}
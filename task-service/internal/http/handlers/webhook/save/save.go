@@ -0,0 +1,133 @@
+package save
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/http/handlers/validators"
+	"task-service/internal/http/middleware/auth"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator"
+	"github.com/google/uuid"
+)
+
+// swagger:model
+type Request struct {
+	// example: https://ci.example.com/hooks/tasks
+	URL string `json:"url" validate:"required,max=2048,webhook_url"`
+
+	// Events to deliver, all events if empty
+	// example: ["TaskCreated","TaskDeleted"]
+	Events []string `json:"events" validate:"max=3,dive,event_valid"`
+
+	// Key of the HMAC-SHA256 signature, generated if empty
+	// example: 3f9a1c0e5b7d4e2f8a6c1b0d9e7f5a3c
+	Secret string `json:"secret" validate:"omitempty,min=16,max=255"`
+
+	// example: true
+	Active *bool `json:"active,omitempty"`
+}
+
+type Response struct {
+	response.Response
+
+	// example: 5a0e8f4c-1d2b-4c3a-9e8f-7b6a5c4d3e2f
+	Id string `json:"id,omitempty"`
+
+	// Returned only here, store it to verify signatures
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookSaver interface {
+	SaveWebhook(webhook domain.Webhook) error
+}
+
+// @Summary Create webhook
+// @Description Subscribe a URL to events of the user's tasks. Every delivery is a POST signed with HMAC-SHA256 of "<X-Webhook-Timestamp>.<body>" in the X-Webhook-Signature header.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param request body Request true "Request"
+// @Security BearerAuth
+// @Success 201 {object} Response "Webhook created successfully"
+// @Failure 400 {object} response.Response "Invalid request"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Failure 500 {object} response.Response "Failed to save webhook"
+// @Router /webhook [post]
+func New(log *slog.Logger, webhookSaver WebhookSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.webhook.save.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		var req Request
+
+		if err := render.DecodeJSON(r.Body, &req); err != nil {
+			log.Error("Failed to decode request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Failed to decode request"))
+			return
+		}
+
+		validate := validator.New()
+		validate.RegisterValidation("webhook_url", validators.IsValidWebhookURL)
+		validate.RegisterValidation("event_valid", validators.IsValidEventType)
+
+		if err := validate.Struct(req); err != nil {
+			log.Error("Invalid request", sl.Error(err))
+			render.JSON(w, r, response.ErrorClient("Invalid request"))
+			return
+		}
+
+		if req.Secret == "" {
+			secret, err := newSecret()
+			if err != nil {
+				log.Error("Failed to generate secret", sl.Error(err))
+				render.JSON(w, r, response.Error("Failed to save webhook"))
+				return
+			}
+			req.Secret = secret
+		}
+
+		webhook := domain.Webhook{
+			Id:        uuid.New(),
+			OwnerId:   auth.UserId(r.Context()),
+			URL:       req.URL,
+			Secret:    req.Secret,
+			Active:    req.Active == nil || *req.Active,
+			CreatedAt: time.Now().UTC(),
+		}
+		for _, event := range req.Events {
+			webhook.Events = append(webhook.Events, domain.EventType(event))
+		}
+
+		if err := webhookSaver.SaveWebhook(webhook); err != nil {
+			log.Error("Failed to save webhook", sl.Error(err))
+			render.JSON(w, r, response.Error("Failed to save webhook"))
+			return
+		}
+
+		log.Info("Webhook created", slog.String("WebhookId", webhook.Id.String()))
+
+		render.Status(r, http.StatusCreated)
+		render.JSON(w, r, Response{
+			Response: response.StatusCreated(),
+			Id:       webhook.Id.String(),
+			Secret:   webhook.Secret,
+		})
+	}
+}
+
+func newSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,362 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"task-service/domain"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/token"
+	"task-service/internal/lib/workflow"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+// session is one board connection. Only run writes to the connection; the
+// reader hands its replies over through out.
+type session struct {
+	log       *slog.Logger
+	conn      *websocket.Conn
+	ownerId   uuid.UUID
+	parser    TokenParser
+	taskMover TaskMover
+	wf        *workflow.Workflow
+
+	// expires is read and written by the reader only, renewed is how the
+	// reader tells run about a new expiry.
+	expires time.Time
+	renewed chan time.Time
+
+	// tasks is only used by the reader, run filters events with a copy.
+	tasks      map[uuid.UUID]struct{}
+	subscribed chan map[uuid.UUID]struct{}
+
+	out chan Reply
+}
+
+func (s *session) run(ctx context.Context, subscriber Subscriber) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.renewed = make(chan time.Time, 1)
+	s.subscribed = make(chan map[uuid.UUID]struct{}, 1)
+	s.conn.MaxPayloadBytes = maxMessageBytes
+
+	// The connection outlives the deadlines of the HTTP server.
+	s.conn.SetDeadline(time.Time{})
+
+	client := subscriber.Subscribe(s.ownerId, outBuffer)
+	defer subscriber.Unsubscribe(client)
+
+	expiry := time.NewTimer(time.Until(s.expires))
+	defer expiry.Stop()
+
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		s.read(ctx)
+	}()
+
+	defer func() {
+		s.conn.Close()
+		<-readerDone
+	}()
+
+	s.log.Info("Board connection opened")
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	tasks := map[uuid.UUID]struct{}{}
+
+	for {
+		select {
+		case <-readerDone:
+			// Send what the reader queued last, such as a refused token.
+			for len(s.out) > 0 {
+				if s.write(<-s.out) != nil {
+					break
+				}
+			}
+			s.log.Info("Board connection closed")
+			return
+		case <-client.Done():
+			s.log.Info("Board connection dropped, closing", sl.Error(client.Err()))
+			return
+		case <-expiry.C:
+			s.log.Info("Access token expired, closing")
+			reply := Reply{Type: "error"}
+			reply.set(response.ErrorUnauthorized("Access token expired"))
+			s.write(reply)
+			return
+		case expires := <-s.renewed:
+			expiry.Reset(time.Until(expires))
+		case tasks = <-s.subscribed:
+		case <-ping.C:
+			if err := s.write(Reply{Type: "ping"}); err != nil {
+				return
+			}
+		case reply := <-s.out:
+			if err := s.write(reply); err != nil {
+				return
+			}
+		case event := <-client.Events():
+			if _, ok := tasks[event.TaskId]; !ok {
+				continue
+			}
+			err := s.write(Reply{
+				Type: "event",
+				Event: &Event{
+					Id:     event.Id,
+					Type:   string(event.Type),
+					TaskId: event.TaskId.String(),
+					Data:   event.Payload,
+				},
+			})
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func ackReply(id string, resp response.Response) Reply {
+	reply := Reply{Type: "ack", Id: id}
+	reply.set(resp)
+	return reply
+}
+
+func (s *session) write(reply Reply) error {
+	s.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	if err := websocket.JSON.Send(s.conn, reply); err != nil {
+		s.log.Info("Failed to write to board connection", sl.Error(err))
+		return err
+	}
+	return nil
+}
+
+// read handles requests until the connection fails or the client is silent
+// for longer than pongWait.
+func (s *session) read(ctx context.Context) {
+	for {
+		s.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var req Request
+		if err := websocket.JSON.Receive(s.conn, &req); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				if !s.reply(ctx, ackReply("", response.ErrorClient("Invalid message"))) {
+					return
+				}
+				continue
+			}
+			return
+		}
+
+		var reply Reply
+		switch req.Type {
+		case "pong":
+			continue
+		case "ping":
+			reply = Reply{Type: "pong", Id: req.Id}
+		case "subscribe":
+			reply = s.subscribe(req)
+		case "unsubscribe":
+			reply = s.unsubscribe(req)
+		case "move":
+			reply = s.move(ctx, req)
+		case "auth":
+			reply = s.auth(req)
+		default:
+			reply = ackReply(req.Id, response.ErrorClient("Unknown message type"))
+		}
+
+		if !s.reply(ctx, reply) {
+			return
+		}
+		if reply.Type == "ack" && reply.Status == http.StatusUnauthorized {
+			return
+		}
+	}
+}
+
+// reply queues a reply, waiting while the client is not reading.
+func (s *session) reply(ctx context.Context, reply Reply) bool {
+	select {
+	case s.out <- reply:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *session) subscribe(req Request) Reply {
+	ids, ok := parseIds(req.TaskIds)
+	if !ok {
+		return ackReply(req.Id, response.ErrorClient("Invalid task id"))
+	}
+
+	for _, id := range ids {
+		if _, ok := s.tasks[id]; ok {
+			continue
+		}
+		if len(s.tasks) >= maxSubscriptions {
+			return s.ack(req, response.ErrorClient("Too many subscriptions"))
+		}
+
+		// Only tasks of the user can be watched.
+		_, err := s.taskMover.GetTaskById(s.ownerId, id)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return s.ack(req, response.ErrorNotFound("Task not found: "+id.String()))
+		}
+		if err != nil {
+			s.log.Error("Failed to get task", sl.Error(err))
+			return s.ack(req, response.Error("Failed to subscribe"))
+		}
+
+		s.tasks[id] = struct{}{}
+	}
+
+	return s.ack(req, response.StatusOK())
+}
+
+func (s *session) unsubscribe(req Request) Reply {
+	ids, ok := parseIds(req.TaskIds)
+	if !ok {
+		return ackReply(req.Id, response.ErrorClient("Invalid task id"))
+	}
+
+	for _, id := range ids {
+		delete(s.tasks, id)
+	}
+
+	return s.ack(req, response.StatusOK())
+}
+
+// ack acknowledges a subscription change with the current subscriptions and
+// hands a copy of them to run.
+func (s *session) ack(req Request, resp response.Response) Reply {
+	tasks := make(map[uuid.UUID]struct{}, len(s.tasks))
+	ids := make([]string, 0, len(s.tasks))
+	for id := range s.tasks {
+		tasks[id] = struct{}{}
+		ids = append(ids, id.String())
+	}
+
+	select {
+	case <-s.subscribed:
+	default:
+	}
+	s.subscribed <- tasks
+
+	reply := ackReply(req.Id, resp)
+	reply.TaskIds = ids
+	return reply
+}
+
+// move changes the status of a task the way PATCH /task/{id} does: the
+// transition must be allowed by the workflow and the task must not be blocked.
+func (s *session) move(ctx context.Context, req Request) Reply {
+	reply := Reply{Type: "ack", Id: req.Id}
+
+	id, err := uuid.Parse(req.TaskId)
+	if err != nil {
+		reply.set(response.ErrorClient("Invalid task id"))
+		return reply
+	}
+
+	status := domain.TaskStatus(req.TaskStatus)
+	if status != domain.TODO && status != domain.IN_PROGRESS && status != domain.DONE {
+		reply.set(response.ErrorClient("Invalid task status"))
+		return reply
+	}
+
+	err = s.taskMover.UpdateTaskById(ctx, s.ownerId, id, domain.Task{TaskStatus: status, Version: req.Version}, s.wf.Check)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		reply.set(response.ErrorNotFound("Task not found"))
+		return reply
+	}
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		reply.set(response.ErrorPreconditionFailed("Task was changed"))
+		if current, err := s.taskMover.GetTaskById(s.ownerId, id); err == nil {
+			reply.Version = current.Version
+		}
+		return reply
+	}
+	var transitionErr *domain.TransitionError
+	if errors.As(err, &transitionErr) {
+		reply.set(response.ErrorConflict(transitionErr.Error()))
+		return reply
+	}
+	var blockedErr *domain.BlockedError
+	if errors.As(err, &blockedErr) {
+		for _, task := range blockedErr.Blockers {
+			reply.Blockers = append(reply.Blockers, Blocker{
+				Id:         task.Id.String(),
+				Title:      task.Title,
+				TaskStatus: string(task.TaskStatus),
+			})
+		}
+		reply.set(response.ErrorConflict("Task is blocked by unfinished tasks"))
+		return reply
+	}
+	if err != nil {
+		s.log.Error("Failed to move task", sl.Error(err))
+		reply.set(response.Error("Failed to move task"))
+		return reply
+	}
+
+	updated, err := s.taskMover.GetTaskById(s.ownerId, id)
+	if err != nil {
+		s.log.Error("Failed to load moved task", sl.Error(err))
+		reply.set(response.StatusOK())
+		return reply
+	}
+
+	s.log.Info("Task moved", slog.String("TaskId", id.String()), slog.String("task_status", string(status)))
+
+	reply.set(response.StatusOK())
+	reply.Version = updated.Version
+	return reply
+}
+
+// auth replaces the access token of the connection. A token of another user
+// is refused and closes the connection.
+func (s *session) auth(req Request) Reply {
+	ownerId, expires, err := s.parser.ParseWithExpiry(req.Token, token.Access)
+	if err != nil || ownerId != s.ownerId {
+		s.log.Info("Invalid access token", sl.Error(err))
+		return ackReply(req.Id, response.ErrorUnauthorized("Invalid access token"))
+	}
+
+	s.expires = expires
+	select {
+	case <-s.renewed:
+	default:
+	}
+	s.renewed <- expires
+
+	return ackReply(req.Id, response.StatusOK())
+}
+
+func parseIds(raw []string) ([]uuid.UUID, bool) {
+	if len(raw) == 0 || len(raw) > maxSubscriptions {
+		return nil, false
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, value := range raw {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
@@ -0,0 +1,178 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task-service/domain"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/hub"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/token"
+	"task-service/internal/lib/workflow"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// pingInterval is how often the server pings. A client that sends
+	// nothing, not even a pong, for pongWait is disconnected.
+	pingInterval = 25 * time.Second
+	pongWait     = 60 * time.Second
+	writeWait    = 10 * time.Second
+
+	// outBuffer is how many replies and events may wait for a slow client.
+	// When it is full, requests of the client are not read until it drains;
+	// a client too slow for its events is disconnected by the hub.
+	outBuffer = 256
+
+	maxMessageBytes  = 64 << 10
+	maxSubscriptions = 500
+)
+
+// Request is a message from the client. Id is chosen by the client and is
+// echoed in the acknowledgement.
+//
+// swagger:model
+type Request struct {
+	// enum: subscribe, unsubscribe, move, auth, ping, pong
+	Type string `json:"type"`
+
+	Id string `json:"id,omitempty"`
+
+	// For subscribe and unsubscribe
+	TaskIds []string `json:"task_ids,omitempty"`
+
+	// For move
+	TaskId     string `json:"task_id,omitempty"`
+	TaskStatus string `json:"task_status,omitempty"`
+	// Only move if the task still has this version, 0 to move anyway
+	Version int64 `json:"version,omitempty"`
+
+	// For auth: a fresh access token before the current one expires
+	Token string `json:"token,omitempty"`
+}
+
+// Reply is a message from the server: an acknowledgement of a request, a
+// change of a subscribed task, or a heartbeat.
+type Reply struct {
+	// enum: ack, event, ping, pong, error
+	Type string `json:"type"`
+	Id   string `json:"id,omitempty"`
+
+	// Outcome of the request for ack, like the HTTP status of a response
+	Status int    `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+
+	// Subscribed tasks after subscribe and unsubscribe
+	TaskIds []string `json:"task_ids,omitempty"`
+
+	// Version of the task after move
+	Version int64 `json:"version,omitempty"`
+
+	// Unfinished tasks blocking a move
+	Blockers []Blocker `json:"blockers,omitempty"`
+
+	Event *Event `json:"event,omitempty"`
+}
+
+func (r *Reply) set(resp response.Response) {
+	r.Status = resp.Status
+	r.Error = resp.Error
+}
+
+type Blocker struct {
+	Id         string `json:"id"`
+	Title      string `json:"title"`
+	TaskStatus string `json:"task_status"`
+}
+
+type Event struct {
+	Id     int64           `json:"id"`
+	Type   string          `json:"type"`
+	TaskId string          `json:"task_id"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type TaskMover interface {
+	GetTaskById(ownerId, id uuid.UUID) (domain.Task, error)
+	UpdateTaskById(ctx context.Context, ownerId, id uuid.UUID, updates domain.Task, check domain.StatusCheck) error
+}
+
+type TokenParser interface {
+	ParseWithExpiry(raw string, kind token.Kind) (uuid.UUID, time.Time, error)
+}
+
+type Subscriber interface {
+	Subscribe(ownerId uuid.UUID, buffer int) *hub.Client
+	Unsubscribe(client *hub.Client)
+}
+
+// @Summary Board WebSocket
+// @Description Bidirectional board channel. Authenticate with the Authorization header or, from browsers, the access_token query parameter. Messages are JSON: subscribe and unsubscribe with task_ids, move with task_id, task_status and optional version, auth with a fresh token, ping and pong. Every request with an id is acknowledged with an ack carrying the same id and an HTTP-like status. Changes of subscribed tasks arrive as event messages. The connection is closed when the access token expires unless a new one is sent with auth.
+// @Tags Task
+// @Param access_token query string false "Access token, for clients that cannot set headers"
+// @Success 101 {string} string "Switching protocols"
+// @Failure 401 {object} response.Response "Unauthorized"
+// @Router /ws [get]
+func New(log *slog.Logger, parser TokenParser, taskMover TaskMover, subscriber Subscriber, wf *workflow.Workflow) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.ws.New"
+
+		log = log.With(slog.String("op", op), slog.String("request_id", middleware.GetReqID(r.Context())))
+
+		raw, ok := accessToken(r)
+		if !ok {
+			log.Info("Missing access token")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.ErrorUnauthorized("Missing access token"))
+			return
+		}
+
+		ownerId, expires, err := parser.ParseWithExpiry(raw, token.Access)
+		if err != nil {
+			log.Info("Invalid access token", sl.Error(err))
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, response.ErrorUnauthorized("Invalid access token"))
+			return
+		}
+
+		server := websocket.Server{
+			// Tokens are not sent by browsers on their own, so requests from
+			// other origins cannot act for the user: any origin is allowed.
+			Handshake: func(*websocket.Config, *http.Request) error { return nil },
+			Handler: func(conn *websocket.Conn) {
+				s := &session{
+					log:       log.With(slog.String("owner_id", ownerId.String())),
+					conn:      conn,
+					ownerId:   ownerId,
+					expires:   expires,
+					parser:    parser,
+					taskMover: taskMover,
+					wf:        wf,
+					tasks:     make(map[uuid.UUID]struct{}),
+					out:       make(chan Reply, outBuffer),
+				}
+				s.run(r.Context(), subscriber)
+			},
+		}
+
+		server.ServeHTTP(w, r)
+	}
+}
+
+func accessToken(r *http.Request) (string, bool) {
+	if raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && raw != "" {
+		return raw, true
+	}
+	if raw := r.URL.Query().Get("access_token"); raw != "" {
+		return raw, true
+	}
+	return "", false
+}
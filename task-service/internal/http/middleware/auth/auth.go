@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"task-service/internal/lib/api/response"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/token"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+type ctxKey struct{}
+
+type TokenParser interface {
+	Parse(raw string, kind token.Kind) (uuid.UUID, error)
+}
+
+// New rejects requests without a valid access token and stores the caller's
+// user id in the request context.
+func New(log *slog.Logger, parser TokenParser) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/auth"),
+		)
+
+		log.Info("auth middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			raw, ok := bearerToken(r)
+			if !ok {
+				log.Info("Missing bearer token", slog.String("request_id", middleware.GetReqID(r.Context())))
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorUnauthorized("Missing access token"))
+				return
+			}
+
+			userId, err := parser.Parse(raw, token.Access)
+			if err != nil {
+				log.Info("Invalid access token", slog.String("request_id", middleware.GetReqID(r.Context())), sl.Error(err))
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, response.ErrorUnauthorized("Invalid access token"))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUserId(r.Context(), userId)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// WithUserId returns a copy of ctx carrying the caller's user id, for
+// transports authenticating on their own.
+func WithUserId(ctx context.Context, userId uuid.UUID) context.Context {
+	return context.WithValue(ctx, ctxKey{}, userId)
+}
+
+// UserId returns the id of the authenticated caller. It is uuid.Nil when the
+// request did not pass through the middleware.
+func UserId(ctx context.Context) uuid.UUID {
+	userId, _ := ctx.Value(ctxKey{}).(uuid.UUID)
+	return userId
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	raw, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || raw == "" {
+		return "", false
+	}
+	return raw, true
+}
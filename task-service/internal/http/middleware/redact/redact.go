@@ -0,0 +1,57 @@
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const placeholder = "REDACTED"
+
+// New hides the values of the query parameters from the request URI, which
+// the request log prints in full. Handlers still read them from r.URL.
+func New(params ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			if uri, ok := redact(r.RequestURI, params); ok {
+				r2 := *r
+				r2.RequestURI = uri
+				r = &r2
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func redact(uri string, params []string) (string, bool) {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri, false
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		// Not parsed by the handlers either, but may still hold a secret.
+		return path + "?" + placeholder, true
+	}
+
+	changed := false
+	for _, param := range params {
+		values, ok := query[param]
+		if !ok {
+			continue
+		}
+		for i := range values {
+			values[i] = placeholder
+		}
+		changed = true
+	}
+	if !changed {
+		return uri, false
+	}
+
+	return path + "?" + query.Encode(), true
+}
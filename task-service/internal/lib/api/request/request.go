@@ -0,0 +1,34 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Time is an optional date of an update. Unlike *time.Time it tells an
+// omitted field, which keeps the date, from null, which clears it.
+type Time struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (t *Time) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		t.Value = nil
+		return nil
+	}
+
+	var value time.Time
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	t.Value = &value
+	return nil
+}
+
+// Clear reports whether the date is to be removed.
+func (t Time) Clear() bool {
+	return t.Set && t.Value == nil
+}
@@ -1,34 +1,62 @@
-package response
-
-import "net/http"
-
-type Response struct {
-	Status int    `json:"status"`
-	Error  string `json:"error,omitempty"`
-}
-
-func StatusCreated() Response {
-	return Response{
-		Status: http.StatusCreated,
-	}
-}
-
-func StatusOK() Response {
-	return Response{
-		Status: http.StatusOK,
-	}
-}
-
-func Error(msg string) Response {
-	return Response{
-		Status: http.StatusInternalServerError,
-		Error:  msg,
-	}
-}
-
-func ErrorClient(msg string) Response {
-	return Response{
-		Status: http.StatusBadRequest,
-		Error:  msg,
-	}
-}
+package response
+
+import "net/http"
+
+type Response struct {
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func StatusCreated() Response {
+	return Response{
+		Status: http.StatusCreated,
+	}
+}
+
+func StatusOK() Response {
+	return Response{
+		Status: http.StatusOK,
+	}
+}
+
+func Error(msg string) Response {
+	return Response{
+		Status: http.StatusInternalServerError,
+		Error:  msg,
+	}
+}
+
+func ErrorClient(msg string) Response {
+	return Response{
+		Status: http.StatusBadRequest,
+		Error:  msg,
+	}
+}
+
+func ErrorConflict(msg string) Response {
+	return Response{
+		Status: http.StatusConflict,
+		Error:  msg,
+	}
+}
+
+func ErrorUnauthorized(msg string) Response {
+	return Response{
+		Status: http.StatusUnauthorized,
+		Error:  msg,
+	}
+}
+
+func ErrorPreconditionFailed(msg string) Response {
+	return Response{
+		Status: http.StatusPreconditionFailed,
+		Error:  msg,
+	}
+}
+
+func ErrorNotFound(msg string) Response {
+	return Response{
+		Status: http.StatusNotFound,
+		Error:  msg,
+	}
+}
@@ -0,0 +1,11 @@
+package response
+
+import "time"
+
+// FormatTime formats an optional date of a response, empty without one.
+func FormatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,114 @@
+package breaker
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned instead of calling a backend that keeps failing.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// Breaker stops calls to a backend after threshold failures in a row. Once
+// timeout has passed, a single trial call is let through: if it succeeds the
+// breaker closes, otherwise it stays open for another timeout.
+type Breaker struct {
+	log       *slog.Logger
+	threshold int
+	timeout   time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+}
+
+// New returns a closed breaker. A threshold of 0 or less never opens it.
+func New(log *slog.Logger, name string, threshold int, timeout time.Duration) *Breaker {
+	return &Breaker{
+		log:       log.With(slog.String("component", "breaker"), slog.String("backend", name)),
+		threshold: threshold,
+		timeout:   timeout,
+	}
+}
+
+// Allow returns ErrOpen if the call must not be made. Every allowed call must
+// be followed by Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case Open:
+		if time.Since(b.openedAt) < b.timeout {
+			return ErrOpen
+		}
+		b.setState(HalfOpen)
+		return nil
+	case HalfOpen:
+		// The trial call is still running.
+		return ErrOpen
+	default:
+		return nil
+	}
+}
+
+// Record counts the outcome of an allowed call, nil being a success.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		if b.state != Closed {
+			b.setState(Closed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == HalfOpen || (b.threshold > 0 && b.failures >= b.threshold && b.state == Closed) {
+		b.openedAt = time.Now()
+		b.setState(Open)
+	}
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *Breaker) setState(state State) {
+	b.state = state
+
+	switch state {
+	case Open:
+		b.log.Warn("circuit breaker opened", slog.Int("failures", b.failures), slog.String("retry_in", b.timeout.String()))
+	case HalfOpen:
+		b.log.Info("circuit breaker half-open, trying backend")
+	case Closed:
+		b.log.Info("circuit breaker closed, backend is available")
+	}
+}
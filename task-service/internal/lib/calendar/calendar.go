@@ -0,0 +1,57 @@
+package calendar
+
+import (
+	"task-service/domain"
+	"time"
+)
+
+// Occurrence returns the start of the n-th occurrence (n = 0 is start itself)
+// of a series repeating every repeat period. Dates are computed on the wall
+// clock of loc, so the time of day survives DST changes. Every occurrence is
+// derived from start rather than from the previous one: a series started on
+// Jan 31 yields Feb 28 (Feb 29 in leap years), Mar 31, Apr 30 and so on, and
+// a yearly series started on Feb 29 falls on Feb 28 in non-leap years.
+//
+// The result is in UTC. ok is false for NEVER and unknown repeat types.
+func Occurrence(start time.Time, repeat domain.TaskRepeatType, n int, loc *time.Location) (t time.Time, ok bool) {
+	local := start.In(loc)
+	year, month, day := local.Date()
+	hour, min, sec := local.Clock()
+	nsec := local.Nanosecond()
+
+	switch repeat {
+	case domain.DAILY:
+		day += n
+	case domain.WEEKLY:
+		day += 7 * n
+	case domain.MONTHLY:
+		year, month, day = addMonths(year, month, day, n)
+	case domain.YEARLY:
+		year, month, day = addMonths(year, month, day, 12*n)
+	default:
+		return time.Time{}, false
+	}
+
+	return time.Date(year, month, day, hour, min, sec, nsec, loc).UTC(), true
+}
+
+func addMonths(year int, month time.Month, day int, n int) (int, time.Month, int) {
+	total := int(month) - 1 + n
+	year += total / 12
+	total %= 12
+	if total < 0 {
+		total += 12
+		year--
+	}
+	month = time.Month(total + 1)
+
+	if last := daysIn(year, month); day > last {
+		day = last
+	}
+
+	return year, month, day
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,62 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+	"task-service/domain"
+	"time"
+)
+
+// Task returns the entity tag of a task. The tag starts with the version;
+// what the version does not cover is appended: the progress of tasks with
+// subtasks, so a finished subtask changes the tag of its parent, and whether
+// the task is overdue.
+func Task(task domain.Task, progress domain.Progress, now time.Time) string {
+	tag := strconv.FormatInt(task.Version, 10)
+	if progress.Total > 0 {
+		tag += "." + strconv.Itoa(progress.Done) + "." + strconv.Itoa(progress.Total)
+	}
+	if task.IsOverdue(now) {
+		tag += ".overdue"
+	}
+	return `"` + tag + `"`
+}
+
+// Version returns the task version an If-Match header asks for. Version is 0
+// for an empty header and for "*", which match any version. ok is false if
+// the header cannot match any task: weak tags, lists and malformed values.
+func Version(header string) (version int64, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+
+	tag, _, _ := strings.Cut(header[1:len(header)-1], ".")
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// NoneMatch reports whether an If-None-Match header matches tag, that is
+// whether the client already has the current representation.
+func NoneMatch(header, tag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
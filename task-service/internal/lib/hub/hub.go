@@ -0,0 +1,151 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrSlow is why a client that did not keep up with its events was dropped.
+	ErrSlow = errors.New("subscriber fell behind")
+	// ErrClosed is why clients are dropped when the hub is closed.
+	ErrClosed = errors.New("event hub closed")
+)
+
+// Hub fans domain events out to the live subscribers of their owner, such as
+// open SSE streams.
+type Hub struct {
+	log     *slog.Logger
+	mu      sync.Mutex
+	clients map[*Client]struct{}
+	closed  bool
+}
+
+// Client receives the events of one owner. A client that does not keep up is
+// dropped: Done is closed and no more events are sent, so it can reconnect
+// and resume from the last event it handled.
+type Client struct {
+	ownerId uuid.UUID
+	events  chan domain.Event
+	done    chan struct{}
+	err     error
+}
+
+func (c *Client) Events() <-chan domain.Event {
+	return c.events
+}
+
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err tells why the client was dropped, ErrSlow or ErrClosed. It is only
+// set once Done is closed.
+func (c *Client) Err() error {
+	return c.err
+}
+
+func New(log *slog.Logger) *Hub {
+	return &Hub{
+		log:     log.With(slog.String("component", "hub")),
+		clients: make(map[*Client]struct{}),
+	}
+}
+
+// Run broadcasts events until the channel is closed or ctx is done.
+func (h *Hub) Run(ctx context.Context, events <-chan domain.Event) {
+	h.log.Info("event hub started")
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.log.Info("event hub stopped")
+			return
+		case event, ok := <-events:
+			if !ok {
+				h.log.Info("event hub stopped")
+				return
+			}
+			h.broadcast(event)
+		}
+	}
+}
+
+// Subscribe registers a client for the events of the owner, buffering up to
+// buffer events. After Close the client is returned already dropped.
+func (h *Hub) Subscribe(ownerId uuid.UUID, buffer int) *Client {
+	client := &Client{
+		ownerId: ownerId,
+		events:  make(chan domain.Event, buffer),
+		done:    make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		client.err = ErrClosed
+		close(client.done)
+		return client
+	}
+	h.clients[client] = struct{}{}
+
+	return client
+}
+
+func (h *Hub) Unsubscribe(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.drop(client, nil)
+}
+
+// Close drops every client with ErrClosed, so the streams serving them end
+// and a shutdown does not wait for them.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+
+	h.log.Info("closing event hub", slog.Int("clients", len(h.clients)))
+	for client := range h.clients {
+		h.drop(client, ErrClosed)
+	}
+}
+
+func (h *Hub) broadcast(event domain.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for client := range h.clients {
+		if client.ownerId != event.OwnerId {
+			continue
+		}
+
+		select {
+		case client.events <- event:
+		default:
+			h.log.Warn("Dropping slow subscriber", slog.String("owner_id", client.ownerId.String()))
+			h.drop(client, ErrSlow)
+		}
+	}
+}
+
+// drop removes the client, h.mu must be held.
+func (h *Hub) drop(client *Client, err error) {
+	if _, ok := h.clients[client]; !ok {
+		return
+	}
+	delete(h.clients, client)
+	client.err = err
+	close(client.done)
+}
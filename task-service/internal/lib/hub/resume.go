@@ -0,0 +1,73 @@
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+// replayBatch is how many missed events are read at once.
+const replayBatch = 500
+
+// ErrReplay is returned by Replay when the missed events cannot be read.
+var ErrReplay = errors.New("failed to list missed events")
+
+type EventLister interface {
+	ListEvents(ownerId uuid.UUID, after int64, limit int) ([]domain.Event, error)
+}
+
+// Resume merges the missed events of a resumed stream with the live ones of
+// its client. The client subscribes before Replay, so nothing published in
+// between is lost, and Live skips the events that were already replayed.
+type Resume struct {
+	last     int64
+	replayed map[int64]struct{}
+}
+
+// Replay sends the events of the owner after the given id in order, reading
+// them from the outbox. An error of send is returned as is.
+func Replay(lister EventLister, ownerId uuid.UUID, after int64, send func(domain.Event) error) (*Resume, error) {
+	r := &Resume{last: after}
+	if after <= 0 {
+		return r, nil
+	}
+
+	r.replayed = make(map[int64]struct{})
+	for {
+		missed, err := lister.ListEvents(ownerId, r.last, replayBatch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrReplay, err)
+		}
+		for _, event := range missed {
+			r.last = event.Id
+			r.replayed[event.Id] = struct{}{}
+			if err := send(event); err != nil {
+				return nil, err
+			}
+		}
+		if len(missed) < replayBatch {
+			return r, nil
+		}
+	}
+}
+
+// Live reports whether a live event is to be sent, false if it was replayed.
+// Events are published in id order, so once one is past the replayed ones,
+// none of the later ones was replayed and they are no longer looked up.
+func (r *Resume) Live(event domain.Event) bool {
+	if r.replayed == nil {
+		return true
+	}
+	if event.Id > r.last {
+		r.replayed = nil
+		return true
+	}
+
+	if _, ok := r.replayed[event.Id]; ok {
+		delete(r.replayed, event.Id)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package hub
+
+import (
+	"errors"
+	"slices"
+	"task-service/domain"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// outbox lists its events after an id, limit at a time.
+type outbox []domain.Event
+
+func (o outbox) ListEvents(ownerId uuid.UUID, after int64, limit int) ([]domain.Event, error) {
+	var events []domain.Event
+	for _, event := range o {
+		if event.Id > after && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func events(ids ...int64) outbox {
+	o := make(outbox, len(ids))
+	for i, id := range ids {
+		o[i] = domain.Event{Id: id}
+	}
+	return o
+}
+
+func TestResumeSkipsReplayedLiveEvents(t *testing.T) {
+	var sent []int64
+	send := func(event domain.Event) error {
+		sent = append(sent, event.Id)
+		return nil
+	}
+
+	resume, err := Replay(events(3, 4, 5), uuid.New(), 3, send)
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	for _, event := range events(4, 5, 6, 7) {
+		if resume.Live(event) {
+			send(event)
+		}
+	}
+
+	if want := []int64{4, 5, 6, 7}; !slices.Equal(sent, want) {
+		t.Errorf("sent %v, want %v", sent, want)
+	}
+	if resume.replayed != nil {
+		t.Errorf("replayed = %v after the live stream passed it, want nil", resume.replayed)
+	}
+}
+
+func TestReplayReadsInBatches(t *testing.T) {
+	ids := make([]int64, replayBatch+10)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	n := 0
+	resume, err := Replay(events(ids...), uuid.New(), 1, func(domain.Event) error {
+		n++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if n != len(ids)-1 {
+		t.Errorf("replayed %d events, want %d", n, len(ids)-1)
+	}
+	if resume.Live(domain.Event{Id: int64(len(ids))}) {
+		t.Error("Live() = true for the last replayed event")
+	}
+}
+
+func TestReplayReturnsSendError(t *testing.T) {
+	closed := errors.New("stream closed")
+
+	_, err := Replay(events(1, 2), uuid.New(), 0, func(domain.Event) error { return closed })
+	if err != nil {
+		t.Fatalf("Replay() error = %v without last event, want nothing replayed", err)
+	}
+
+	_, err = Replay(events(1, 2), uuid.New(), 1, func(domain.Event) error { return closed })
+	if !errors.Is(err, closed) || errors.Is(err, ErrReplay) {
+		t.Errorf("Replay() error = %v, want %v", err, closed)
+	}
+}
@@ -1,10 +1,10 @@
-package sl
-
-import "log/slog"
-
-func Error(err error) slog.Attr {
-	return slog.Attr{
-		Key:   "error",
-		Value: slog.StringValue(err.Error()),
-	}
-}
+package sl
+
+import "log/slog"
+
+func Error(err error) slog.Attr {
+	return slog.Attr{
+		Key:   "error",
+		Value: slog.StringValue(err.Error()),
+	}
+}
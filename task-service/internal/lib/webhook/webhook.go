@@ -0,0 +1,64 @@
+// Package webhook builds and signs the requests sent to webhook subscribers.
+//
+// A receiver verifies a request by computing HMAC-SHA256 over the timestamp
+// header, a dot and the raw body with the webhook secret and comparing it
+// with the signature header, see Verify. Rejecting timestamps that are too
+// old protects against replayed requests.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"task-service/domain"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+// Body is the JSON body of a delivery. Event is the event id, the same for
+// every delivery and retry of one event, so receivers can drop duplicates.
+type Body struct {
+	Event     int64           `json:"event"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+func NewBody(delivery domain.WebhookDelivery) ([]byte, error) {
+	return json.Marshal(Body{
+		Event:     delivery.EventId,
+		Type:      string(delivery.EventType),
+		CreatedAt: delivery.CreatedAt.UTC(),
+		Data:      delivery.Payload,
+	})
+}
+
+// Sign returns the value of the signature header for body sent at timestamp
+// (Unix seconds).
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature was produced by Sign with the same secret,
+// timestamp and body.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
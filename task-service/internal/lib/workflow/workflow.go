@@ -0,0 +1,73 @@
+package workflow
+
+import (
+	"fmt"
+	"task-service/domain"
+)
+
+// DefaultTransitions is used when the config does not define any. A finished
+// task can only go back to TODO through the reopen action.
+var DefaultTransitions = map[string][]string{
+	string(domain.TODO):        {string(domain.IN_PROGRESS), string(domain.DONE)},
+	string(domain.IN_PROGRESS): {string(domain.TODO), string(domain.DONE)},
+	string(domain.DONE):        {},
+}
+
+// Workflow is the state machine of task statuses.
+type Workflow struct {
+	transitions map[domain.TaskStatus]map[domain.TaskStatus]bool
+}
+
+func New(transitions map[string][]string) (*Workflow, error) {
+	if len(transitions) == 0 {
+		transitions = DefaultTransitions
+	}
+
+	wf := &Workflow{
+		transitions: make(map[domain.TaskStatus]map[domain.TaskStatus]bool, len(transitions)),
+	}
+
+	for from, targets := range transitions {
+		if !isStatus(from) {
+			return nil, fmt.Errorf("unknown task status %q in workflow", from)
+		}
+
+		allowed := make(map[domain.TaskStatus]bool, len(targets))
+		for _, to := range targets {
+			if !isStatus(to) {
+				return nil, fmt.Errorf("unknown task status %q in workflow", to)
+			}
+			allowed[domain.TaskStatus(to)] = true
+		}
+		wf.transitions[domain.TaskStatus(from)] = allowed
+	}
+
+	return wf, nil
+}
+
+// Check returns a *domain.TransitionError if a task may not move from one
+// status to the other. Keeping the current status is always allowed.
+func (wf *Workflow) Check(from, to domain.TaskStatus) error {
+	if from == to || wf.transitions[from][to] {
+		return nil
+	}
+	return &domain.TransitionError{From: from, To: to}
+}
+
+// Reopen is the check of the reopen action: only a DONE task goes back to
+// TODO, whatever the workflow allows.
+func Reopen(from, to domain.TaskStatus) error {
+	if from != domain.DONE {
+		return domain.ErrTaskNotDone
+	}
+	return nil
+}
+
+func isStatus(status string) bool {
+	switch domain.TaskStatus(status) {
+	case domain.TODO, domain.IN_PROGRESS, domain.DONE:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,459 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"task-service/domain"
+	"task-service/internal/config"
+	"task-service/internal/lib/breaker"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/lru"
+	"task-service/internal/repo/postgresql"
+	"task-service/internal/repo/redis"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+// keyPrefix namespaces the cached tasks. Bump the version when the JSON of
+// entry or domain.Task changes, so entries written by older releases are
+// not read.
+const keyPrefix = "task:v4:"
+
+// Repository is postgresql.Repository with tasks cached in Redis. Reads of
+// a single task and of its progress go through the cache, writes store or
+// evict the cached copies of every task they change, and of the ancestors
+// whose progress they change. Cache failures are logged and never fail the
+// call.
+//
+// Concurrent misses of one task share a single database read, and tasks
+// that do not exist are remembered for a short time, so neither a hot task
+// expiring nor lookups of unknown ids pile up on the database. Expiry is
+// jittered so tasks cached together do not expire together. With a stale
+// window, an expired task is still served while it is read again in the
+// background.
+//
+// In front of Redis every replica keeps the most recently read tasks in
+// memory for a few seconds. Writes drop the copies of all replicas through
+// a Pub/Sub channel; if a message is lost, a copy lives at most that long.
+//
+// While Redis is unavailable tasks are read from the database. Tasks whose
+// eviction failed are not read from Redis by this replica until Run has
+// deleted them there; the Invalidator makes up for them on every replica.
+type Repository struct {
+	*postgresql.Repository
+
+	log   *slog.Logger
+	redis *redis.RedisDB
+	local *lru.Cache[uuid.UUID, entry]
+	group singleflight.Group
+
+	pending       pending
+	retryInterval time.Duration
+
+	channel    string
+	ttl        time.Duration
+	jitter     time.Duration
+	missingTTL time.Duration
+	staleTTL   time.Duration
+}
+
+func New(log *slog.Logger, db *postgresql.Repository, rdb *redis.RedisDB, cfg config.Redis) *Repository {
+	return &Repository{
+		Repository: db,
+		log:        log.With(slog.String("component", "repo/cache")),
+		redis:      rdb,
+		local:      lru.New[uuid.UUID, entry](cfg.CacheLocalSize, cfg.CacheLocalTTL),
+		channel:    cfg.CacheChannel,
+		ttl:        cfg.CacheTTL,
+		jitter:     cfg.CacheJitter,
+		missingTTL: cfg.CacheMissingTTL,
+		staleTTL:   cfg.CacheStaleTTL,
+
+		retryInterval: cfg.HealthInterval,
+	}
+}
+
+// Run drops the local copies of the tasks other replicas, and this one,
+// announce as changed until the channel is closed. It also retries the
+// evictions that failed while Redis was unavailable.
+func (r *Repository) Run(ctx context.Context, invalidations <-chan []uuid.UUID) {
+	r.log.Info("cache invalidation started", slog.String("channel", r.channel))
+
+	var retry <-chan time.Time
+	if r.retryInterval > 0 {
+		ticker := time.NewTicker(r.retryInterval)
+		defer ticker.Stop()
+		retry = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("cache invalidation stopped")
+			return
+		case <-retry:
+			r.retryEvictions(ctx)
+		case ids, ok := <-invalidations:
+			if !ok {
+				r.log.Info("cache invalidation stopped")
+				return
+			}
+			r.local.Delete(ids...)
+			stats.Add("invalidations_received", 1)
+		}
+	}
+}
+
+func key(id uuid.UUID) string {
+	return keyPrefix + id.String()
+}
+
+// GetTaskById returns the cached task if it belongs to the owner, otherwise
+// reads it from the database and caches it. A cached miss of the owner is
+// answered with domain.ErrTaskNotFound without asking the database.
+func (r *Repository) GetTaskById(ownerId, id uuid.UUID) (domain.Task, error) {
+	e, err := r.get(ownerId, id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	return *e.Task, nil
+}
+
+// GetTaskProgress returns the progress cached with the task. It is read
+// along with the task and evicted with it when a subtask changes.
+func (r *Repository) GetTaskProgress(ownerId, id uuid.UUID) (domain.Progress, error) {
+	e, err := r.get(ownerId, id)
+	if err != nil {
+		return domain.Progress{}, err
+	}
+
+	return e.Progress, nil
+}
+
+func (r *Repository) get(ownerId, id uuid.UUID) (entry, error) {
+	const op = "repo.cache.get"
+
+	cached, ok := r.lookup(context.Background(), id)
+	if !ok || cached.OwnerId != ownerId {
+		return r.load(ownerId, id)
+	}
+
+	if cached.Task == nil {
+		return entry{}, fmt.Errorf("%s: %w", op, domain.ErrTaskNotFound)
+	}
+
+	if time.Now().After(cached.FreshUntil) {
+		r.refresh(ownerId, id)
+	}
+
+	return cached, nil
+}
+
+// load reads the task and its progress from the database and caches the
+// outcome. Concurrent loads of the same task wait for the first one.
+func (r *Repository) load(ownerId, id uuid.UUID) (entry, error) {
+	v, err, _ := r.group.Do(flightKey(ownerId, id), r.loader(ownerId, id))
+	if err != nil {
+		return entry{}, err
+	}
+
+	return v.(entry), nil
+}
+
+// refresh reloads a stale task in the background, joining a load already
+// running. Stale reads meanwhile keep getting the cached copy; if the load
+// fails, the next read after the stale window reports it.
+func (r *Repository) refresh(ownerId, id uuid.UUID) {
+	r.group.DoChan(flightKey(ownerId, id), r.loader(ownerId, id))
+}
+
+func (r *Repository) loader(ownerId, id uuid.UUID) func() (any, error) {
+	return func() (any, error) {
+		ctx := context.Background()
+
+		stats.Add("loads", 1)
+
+		task, err := r.Repository.GetTaskById(ownerId, id)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			r.storeMissing(ctx, ownerId, id)
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		progress, err := r.Repository.GetTaskProgress(ownerId, id)
+		if err != nil {
+			return nil, err
+		}
+
+		return r.store(ctx, task, progress), nil
+	}
+}
+
+func flightKey(ownerId, id uuid.UUID) string {
+	return ownerId.String() + "/" + id.String()
+}
+
+func (r *Repository) SaveTask(ctx context.Context, entity domain.Task) error {
+	if err := r.Repository.SaveTask(ctx, entity); err != nil {
+		return err
+	}
+
+	// Other replicas may remember the id as missing. A new task has no
+	// subtasks yet, but it is one of its parent's.
+	r.publish(ctx, entity.Id)
+	r.store(ctx, entity, domain.Progress{})
+	if entity.ParentId != nil {
+		r.evict(ctx, r.ancestors(entity.OwnerId, entity.Id)...)
+	}
+	return nil
+}
+
+func (r *Repository) UpdateTaskById(ctx context.Context, ownerId, id uuid.UUID, updates domain.Task, check domain.StatusCheck) error {
+	if err := r.Repository.UpdateTaskById(ctx, ownerId, id, updates, check); err != nil {
+		return err
+	}
+
+	evicted := []uuid.UUID{id}
+	if updates.TaskStatus != "" {
+		evicted = append(evicted, r.ancestors(ownerId, id)...)
+	}
+	r.evict(ctx, evicted...)
+	return nil
+}
+
+// DeleteTaskById also evicts the children when they are kept: they move up
+// to the parent of the deleted task. The ancestors are read before the task
+// is deleted.
+func (r *Repository) DeleteTaskById(ctx context.Context, ownerId, id uuid.UUID, cascade bool, version int64) ([]uuid.UUID, error) {
+	evicted := r.ancestors(ownerId, id)
+	if !cascade {
+		evicted = append(evicted, r.children(ownerId, id)...)
+	}
+
+	deleted, err := r.Repository.DeleteTaskById(ctx, ownerId, id, cascade, version)
+	if err != nil {
+		return nil, err
+	}
+
+	r.evict(ctx, append(deleted, evicted...)...)
+	return deleted, nil
+}
+
+func (r *Repository) RestoreTask(ctx context.Context, ownerId, id uuid.UUID) ([]uuid.UUID, error) {
+	restored, err := r.Repository.RestoreTask(ctx, ownerId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.evict(ctx, append(restored, r.ancestors(ownerId, id)...)...)
+	return restored, nil
+}
+
+func (r *Repository) MoveTask(ctx context.Context, ownerId, id uuid.UUID, projectId *uuid.UUID) error {
+	if err := r.Repository.MoveTask(ctx, ownerId, id, projectId); err != nil {
+		return err
+	}
+
+	r.evict(ctx, id)
+	return nil
+}
+
+// SetTaskParent evicts the old ancestors of the task as well as the new
+// ones.
+func (r *Repository) SetTaskParent(ctx context.Context, ownerId, id uuid.UUID, parentId *uuid.UUID) error {
+	evicted := r.ancestors(ownerId, id)
+	if err := r.Repository.SetTaskParent(ctx, ownerId, id, parentId); err != nil {
+		return err
+	}
+
+	evicted = append(evicted, id)
+	r.evict(ctx, append(evicted, r.ancestors(ownerId, id)...)...)
+	return nil
+}
+
+// ApplyBatch caches the tasks the batch created and updated as the batch
+// left them, written to Redis in one pipeline. It evicts the tasks it
+// deleted, the children kept by deletes without cascade and the ancestors
+// of every task it touched, whose progress changed.
+func (r *Repository) ApplyBatch(ctx context.Context, ownerId uuid.UUID, ops []domain.BatchOp, check domain.StatusCheck) ([]domain.BatchResult, error) {
+	var evicted, deletes []uuid.UUID
+	for _, op := range ops {
+		if op.Action != domain.BatchDelete {
+			continue
+		}
+		deletes = append(deletes, op.Id)
+		if !op.Cascade {
+			evicted = append(evicted, r.children(ownerId, op.Id)...)
+		}
+	}
+	if len(deletes) > 0 {
+		evicted = append(evicted, r.ancestors(ownerId, deletes...)...)
+	}
+
+	applied, err := r.Repository.ApplyBatch(ctx, ownerId, ops, check)
+	if err != nil {
+		return nil, err
+	}
+
+	// Later operations of a batch can change tasks returned by earlier ones:
+	// the last result of a task is how the batch left it.
+	var changed []uuid.UUID
+	latest := make(map[uuid.UUID]domain.Task)
+	for _, result := range applied {
+		if id := result.Task.Id; id != uuid.Nil {
+			if _, ok := latest[id]; !ok {
+				changed = append(changed, id)
+			}
+			latest[id] = result.Task
+		}
+		evicted = append(evicted, result.Deleted...)
+	}
+	if len(changed) > 0 {
+		evicted = append(evicted, r.ancestors(ownerId, changed...)...)
+	}
+
+	// Tasks the batch deleted or moved after changing them are evicted
+	// instead.
+	for _, id := range evicted {
+		delete(latest, id)
+	}
+
+	var stored []domain.Task
+	var storedIds []uuid.UUID
+	for _, id := range changed {
+		if task, ok := latest[id]; ok {
+			stored = append(stored, task)
+			storedIds = append(storedIds, id)
+		}
+	}
+
+	if len(stored) > 0 {
+		progress, err := r.Repository.GetTasksProgress(ownerId, storedIds...)
+		if err != nil {
+			r.log.Error("Failed to get progress", sl.Error(err))
+			evicted = append(evicted, storedIds...)
+		} else {
+			r.publish(ctx, storedIds...)
+			r.writeMany(ctx, stored, progress)
+		}
+	}
+
+	r.evict(ctx, evicted...)
+	return applied, nil
+}
+
+// DeleteProjectById evicts the tasks of the project: they are left without
+// a project.
+func (r *Repository) DeleteProjectById(ctx context.Context, ownerId, id uuid.UUID) ([]uuid.UUID, error) {
+	tasks, err := r.Repository.DeleteProjectById(ctx, ownerId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.evict(ctx, tasks...)
+	return tasks, nil
+}
+
+func (r *Repository) children(ownerId, id uuid.UUID) []uuid.UUID {
+	children, err := r.Repository.GetTaskChildren(ownerId, id)
+	if err != nil {
+		r.log.Error("Failed to get children", slog.String("TaskId", id.String()), sl.Error(err))
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(children))
+	for i, child := range children {
+		ids[i] = child.Id
+	}
+	return ids
+}
+
+// SpawnNextOccurrence evicts the ancestors of the next occurrence: it is a
+// new subtask of its parent.
+func (r *Repository) SpawnNextOccurrence(prev uuid.UUID, next domain.Task) (bool, error) {
+	spawned, err := r.Repository.SpawnNextOccurrence(prev, next)
+	if err != nil || !spawned {
+		return spawned, err
+	}
+
+	if next.ParentId != nil {
+		r.evict(context.Background(), r.ancestors(next.OwnerId, next.Id)...)
+	}
+	return true, nil
+}
+
+// ancestors returns the ancestors of the tasks, whose cached progress
+// changes with them.
+func (r *Repository) ancestors(ownerId uuid.UUID, ids ...uuid.UUID) []uuid.UUID {
+	ancestors, err := r.Repository.GetTaskAncestors(ownerId, ids...)
+	if err != nil {
+		r.log.Error("Failed to get ancestors", sl.Error(err))
+		return nil
+	}
+	return ancestors
+}
+
+// evict drops the cached copies of the tasks in Redis and on every replica.
+func (r *Repository) evict(ctx context.Context, ids ...uuid.UUID) {
+	if len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = key(id)
+	}
+
+	r.local.Delete(ids...)
+	if err := r.redis.Delete(ctx, keys...); err != nil {
+		r.pending.add(ids...)
+		r.failed("Failed to delete tasks from Redis", err)
+	} else {
+		r.pending.remove(ids...)
+	}
+	r.publish(ctx, ids...)
+}
+
+// retryEvictions deletes the tasks whose eviction failed from Redis.
+func (r *Repository) retryEvictions(ctx context.Context) {
+	ids := r.pending.list()
+	if len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = key(id)
+	}
+
+	if err := r.redis.Delete(ctx, keys...); err != nil {
+		r.failed("Failed to delete tasks from Redis", err)
+		return
+	}
+	r.pending.remove(ids...)
+	r.log.Info("evicted tasks left in Redis during outage", slog.Int("count", len(ids)))
+}
+
+func (r *Repository) publish(ctx context.Context, ids ...uuid.UUID) {
+	if err := r.redis.PublishInvalidation(ctx, r.channel, ids...); err != nil {
+		r.failed("Failed to publish cache invalidation", err)
+		return
+	}
+	stats.Add("invalidations_sent", 1)
+}
+
+// failed logs a failed Redis call. Calls the breaker rejected are not
+// logged: it has logged that Redis is unavailable when it opened.
+func (r *Repository) failed(msg string, err error, attrs ...any) {
+	if errors.Is(err, breaker.ErrOpen) {
+		return
+	}
+	r.log.Error(msg, append(attrs, sl.Error(err))...)
+}
@@ -0,0 +1,166 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"task-service/domain"
+	"task-service/internal/lib/breaker"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/repo/redis"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// entry is what is cached for a task id: the task and the progress of its
+// subtasks, or nil if the owner asked for a task that does not exist.
+// FreshUntil is when the entry goes stale; Redis keeps it for the stale
+// window after that.
+//
+// Version is the version of the task, 0 for misses. Redis never replaces an
+// entry with one of a lower version, so a read that raced with a change
+// cannot cache the task as it was before. A tombstone, an entry with only a
+// version, is left by the outbox relay for every change and is read as a
+// miss.
+type entry struct {
+	OwnerId    uuid.UUID       `json:"owner_id"`
+	Task       *domain.Task    `json:"task,omitempty"`
+	Progress   domain.Progress `json:"progress"`
+	Version    int64           `json:"version"`
+	FreshUntil time.Time       `json:"fresh_until"`
+}
+
+func (e entry) tombstone() bool {
+	return e.OwnerId == uuid.Nil
+}
+
+// lookup returns the entry from memory, or from Redis and keeps it in
+// memory.
+func (r *Repository) lookup(ctx context.Context, id uuid.UUID) (entry, bool) {
+	if e, ok := r.local.Get(id); ok {
+		stats.Add("local_hits", 1)
+		return e, true
+	}
+	stats.Add("local_misses", 1)
+
+	if r.pending.has(id) {
+		return entry{}, false
+	}
+
+	cached, err := r.redis.Get(ctx, key(id))
+	if err != nil {
+		if !errors.Is(err, breaker.ErrOpen) {
+			r.log.Info("Failed to get task from Redis", sl.Error(err))
+		}
+		stats.Add("redis_errors", 1)
+		return entry{}, false
+	}
+	if cached == "" {
+		stats.Add("redis_misses", 1)
+		return entry{}, false
+	}
+
+	var e entry
+	if err := json.Unmarshal([]byte(cached), &e); err != nil {
+		r.log.Error("Failed to unmarshal cached task", slog.String("TaskId", id.String()), sl.Error(err))
+		stats.Add("redis_misses", 1)
+		return entry{}, false
+	}
+	if e.tombstone() {
+		stats.Add("redis_misses", 1)
+		return entry{}, false
+	}
+	stats.Add("redis_hits", 1)
+
+	r.local.Set(id, e)
+	return e, true
+}
+
+func (r *Repository) store(ctx context.Context, task domain.Task, progress domain.Progress) entry {
+	return r.write(ctx, task.Id, entry{OwnerId: task.OwnerId, Task: &task, Progress: progress, Version: task.Version}, r.ttl)
+}
+
+// storeMissing remembers that the owner has no task with the id. The entry
+// is overwritten when the task is created and evicted when it is restored.
+func (r *Repository) storeMissing(ctx context.Context, ownerId, id uuid.UUID) {
+	if r.missingTTL <= 0 {
+		return
+	}
+	r.write(ctx, id, entry{OwnerId: ownerId}, r.missingTTL)
+}
+
+// write caches the entry unless Redis already has a higher version of the
+// task. The entry is kept in memory only if Redis took it, or if Redis is
+// unavailable and the memory is all there is.
+func (r *Repository) write(ctx context.Context, id uuid.UUID, e entry, ttl time.Duration) entry {
+	e, value, ok := r.encode(id, e, ttl)
+	if !ok {
+		return e
+	}
+
+	stored, err := r.redis.SetIfNotOlder(ctx, value.Key, value.Value, value.Version, value.TTL)
+	r.written(id, e, stored, err)
+	return e
+}
+
+// writeMany is write for the tasks, sent to Redis in one pipeline.
+func (r *Repository) writeMany(ctx context.Context, tasks []domain.Task, progress map[uuid.UUID]domain.Progress) {
+	entries := make([]entry, 0, len(tasks))
+	values := make([]redis.Versioned, 0, len(tasks))
+	for _, task := range tasks {
+		e, value, ok := r.encode(task.Id, entry{OwnerId: task.OwnerId, Task: &task, Progress: progress[task.Id], Version: task.Version}, r.ttl)
+		if ok {
+			entries = append(entries, e)
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	stored, err := r.redis.SetManyIfNotOlder(ctx, values)
+	for i, e := range entries {
+		r.written(e.Task.Id, e, err == nil && stored[i], err)
+	}
+}
+
+// encode stamps the entry with the time it goes stale and turns it into
+// the value for Redis.
+func (r *Repository) encode(id uuid.UUID, e entry, ttl time.Duration) (entry, redis.Versioned, bool) {
+	if r.jitter > 0 {
+		ttl += rand.N(r.jitter)
+	}
+	e.FreshUntil = time.Now().Add(ttl)
+
+	value, err := json.Marshal(e)
+	if err != nil {
+		r.log.Error("Failed to marshal task", sl.Error(err))
+		return e, redis.Versioned{}, false
+	}
+
+	// Misses are not served stale: the task may have been created since.
+	if e.Task != nil {
+		ttl += r.staleTTL
+	}
+
+	return e, redis.Versioned{Key: key(id), Value: string(value), Version: e.Version, TTL: ttl}, true
+}
+
+// written keeps the entry in memory after Redis took it or failed.
+func (r *Repository) written(id uuid.UUID, e entry, stored bool, err error) {
+	if err != nil {
+		r.failed("Failed to set task in Redis", err, slog.String("TaskId", id.String()))
+		r.local.Set(id, e)
+		return
+	}
+	if !stored {
+		stats.Add("stale_writes", 1)
+		return
+	}
+
+	r.local.Set(id, e)
+	r.pending.remove(id)
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+// Invalidator is the outbox relay's side of the cache. Writes evict the
+// tasks they change right away, but an eviction that fails, because Redis
+// is unavailable or the replica stops, is lost. Every change also queues an
+// event in the outbox, which the relay keeps publishing until it goes
+// through, so the cache is brought up to date for all replicas once Redis
+// is back, even by a replica started since.
+type Invalidator struct {
+	repo *Repository
+}
+
+// Invalidator returns the publisher to hand to the outbox relay. It is best
+// placed first, so a task is evicted before its event is announced.
+func (r *Repository) Invalidator() *Invalidator {
+	return &Invalidator{repo: r}
+}
+
+// Publish leaves a tombstone with the version of the changed task in Redis:
+// the cached copy is dropped and reads that started before the change cannot
+// cache it again. The ancestors are evicted as well, their progress may
+// have changed. An error makes the relay retry the event.
+func (i *Invalidator) Publish(ctx context.Context, event domain.Event) error {
+	r := i.repo
+
+	var payload domain.TaskEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal event %d: %w", event.Id, err)
+	}
+
+	value, err := json.Marshal(entry{Version: payload.Version})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tombstone: %w", err)
+	}
+
+	// The tombstone outlives every entry it replaces.
+	ttl := r.ttl + r.jitter + r.staleTTL
+	if _, err := r.redis.SetIfNotOlder(ctx, key(event.TaskId), string(value), payload.Version, ttl); err != nil {
+		return fmt.Errorf("failed to set tombstone of task %s: %w", event.TaskId, err)
+	}
+
+	evicted := []uuid.UUID{event.TaskId}
+	if payload.ParentId != nil {
+		evicted = append(evicted, r.ancestors(event.OwnerId, event.TaskId)...)
+	}
+	if old, ok := oldParent(payload); ok {
+		evicted = append(evicted, old)
+		evicted = append(evicted, r.ancestors(event.OwnerId, old)...)
+	}
+
+	keys := make([]string, 0, len(evicted)-1)
+	for _, id := range evicted[1:] {
+		keys = append(keys, key(id))
+	}
+	if len(keys) > 0 {
+		if err := r.redis.Delete(ctx, keys...); err != nil {
+			return fmt.Errorf("failed to delete ancestors of task %s: %w", event.TaskId, err)
+		}
+	}
+
+	r.local.Delete(evicted...)
+	if err := r.redis.PublishInvalidation(ctx, r.channel, evicted...); err != nil {
+		return err
+	}
+	r.pending.remove(evicted...)
+	return nil
+}
+
+// oldParent returns the parent a task had before the change, if the change
+// moved it.
+func oldParent(payload domain.TaskEvent) (uuid.UUID, bool) {
+	old, ok := payload.Changes["parent_id"].Old.(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(old)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
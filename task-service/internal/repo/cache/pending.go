@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// pending is the set of tasks whose eviction from Redis failed. Their
+// cached copies may be stale, so they are not read from Redis until they
+// are deleted or overwritten there.
+type pending struct {
+	mu  sync.Mutex
+	ids map[uuid.UUID]struct{}
+}
+
+func (p *pending) add(ids ...uuid.UUID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ids == nil {
+		p.ids = make(map[uuid.UUID]struct{}, len(ids))
+	}
+	for _, id := range ids {
+		p.ids[id] = struct{}{}
+	}
+}
+
+func (p *pending) remove(ids ...uuid.UUID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, id := range ids {
+		delete(p.ids, id)
+	}
+}
+
+func (p *pending) has(id uuid.UUID) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.ids[id]
+	return ok
+}
+
+func (p *pending) list() []uuid.UUID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]uuid.UUID, 0, len(p.ids))
+	for id := range p.ids {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,9 @@
+package cache
+
+import "expvar"
+
+// stats counts how task reads were answered, published as "task_cache" on
+// /debug/vars of the admin server: local_hits and local_misses of the
+// in-memory tier, redis_hits, redis_misses and redis_errors of the ones that
+// missed it, loads from the database and invalidations sent and received.
+var stats = expvar.NewMap("task_cache")
@@ -0,0 +1,174 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"task-service/domain"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// recordChange writes the change of a task to the audit log and queues the
+// matching event in the outbox, both in the same transaction as the change
+// itself. old is nil for created tasks. Updates that change nothing are not
+// recorded. The request id is the one set by chi's RequestID middleware.
+func recordChange(ctx context.Context, tx *sql.Tx, action domain.AuditAction, actor string, old *domain.Task, new domain.Task) error {
+	changes := domain.DiffTasks(old, new)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit changes: %w", err)
+	}
+
+	requestId := middleware.GetReqID(ctx)
+
+	query := `
+		INSERT INTO task_audit (task_id, owner_id, action, actor, request_id, changes)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err = tx.Exec(query, new.Id, new.OwnerId, action, actor, requestId, raw)
+	if err != nil {
+		return fmt.Errorf("failed to record audit event: %w", err)
+	}
+
+	return enqueueEvent(tx, domain.EventTypeFor(action), domain.NewTaskEvent(new, changes, requestId))
+}
+
+// lockTasks returns the tasks with the given ids, deleted ones included,
+// locked for the rest of the transaction.
+func lockTasks(tx *sql.Tx, ids []uuid.UUID) (map[uuid.UUID]domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = ANY($1)
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make(map[uuid.UUID]domain.Task, len(ids))
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks[task.Id] = task
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to lock tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// recordChanges records the change of every task in before, reading their new
+// state in the same transaction.
+func recordChanges(ctx context.Context, tx *sql.Tx, action domain.AuditAction, actor string, before map[uuid.UUID]domain.Task) error {
+	ids := make([]uuid.UUID, 0, len(before))
+	for id := range before {
+		ids = append(ids, id)
+	}
+
+	after, err := lockTasks(tx, ids)
+	if err != nil {
+		return err
+	}
+
+	for id, old := range before {
+		if err := recordChange(ctx, tx, action, actor, &old, after[id]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListAudit returns audit events of the owner, newest first, optionally only
+// those of one task. Events of deleted and purged tasks are kept.
+func (r *Repository) ListAudit(filter domain.AuditFilter) (domain.AuditPage, error) {
+	const op = "repo.postgresql.ListAudit"
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	before := int64(0)
+	if filter.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+		if err != nil {
+			return domain.AuditPage{}, fmt.Errorf("%s: %w", op, domain.ErrInvalidCursor)
+		}
+		before, err = strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return domain.AuditPage{}, fmt.Errorf("%s: %w", op, domain.ErrInvalidCursor)
+		}
+	}
+
+	query := `
+		SELECT id, task_id, owner_id, action, actor, request_id, changes, created_at
+		FROM task_audit
+		WHERE owner_id = $1
+			AND ($2::uuid IS NULL OR task_id = $2)
+			AND ($3::bigint = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(query, filter.OwnerId, filter.TaskId, before, limit+1)
+	if err != nil {
+		return domain.AuditPage{}, fmt.Errorf("%s: failed to list audit events: %w", op, err)
+	}
+	defer rows.Close()
+
+	events := make([]domain.AuditEvent, 0, limit)
+	for rows.Next() {
+		var event domain.AuditEvent
+		var changes []byte
+		err := rows.Scan(
+			&event.Id,
+			&event.TaskId,
+			&event.OwnerId,
+			&event.Action,
+			&event.Actor,
+			&event.RequestId,
+			&changes,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return domain.AuditPage{}, fmt.Errorf("%s: failed to scan audit event: %w", op, err)
+		}
+		if err := json.Unmarshal(changes, &event.Changes); err != nil {
+			return domain.AuditPage{}, fmt.Errorf("%s: failed to decode audit changes: %w", op, err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return domain.AuditPage{}, fmt.Errorf("%s: failed to list audit events: %w", op, err)
+	}
+
+	page := domain.AuditPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		last := page.Events[limit-1].Id
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(last, 10)))
+	}
+
+	return page, nil
+}
@@ -0,0 +1,59 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+// ApplyBatch applies the operations in order in one transaction. Either all
+// of them are applied or none; the failing operation is reported as a
+// *domain.BatchError.
+//
+// Updates check their new status like UpdateTaskById does, against the
+// changes of the earlier operations.
+func (r *Repository) ApplyBatch(ctx context.Context, ownerId uuid.UUID, ops []domain.BatchOp, check domain.StatusCheck) ([]domain.BatchResult, error) {
+	const op = "repo.postgresql.ApplyBatch"
+
+	results := make([]domain.BatchResult, len(ops))
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		for i, batchOp := range ops {
+			result, err := applyBatchOp(ctx, tx, ownerId, batchOp, check)
+			if err != nil {
+				return &domain.BatchError{Index: i, Err: err}
+			}
+			results[i] = result
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func applyBatchOp(ctx context.Context, tx *sql.Tx, ownerId uuid.UUID, batchOp domain.BatchOp, check domain.StatusCheck) (domain.BatchResult, error) {
+	switch batchOp.Action {
+	case domain.BatchCreate:
+		if err := saveTask(ctx, tx, batchOp.Task); err != nil {
+			return domain.BatchResult{}, err
+		}
+		task, err := getTask(tx, ownerId, batchOp.Task.Id)
+		return domain.BatchResult{Task: task}, err
+	case domain.BatchUpdate:
+		if err := updateTask(ctx, tx, ownerId, batchOp.Id, batchOp.Task, check); err != nil {
+			return domain.BatchResult{}, err
+		}
+		task, err := getTask(tx, ownerId, batchOp.Id)
+		return domain.BatchResult{Task: task}, err
+	case domain.BatchDelete:
+		deleted, err := deleteTask(ctx, tx, ownerId, batchOp.Id, batchOp.Cascade, batchOp.Task.Version)
+		return domain.BatchResult{Deleted: deleted}, err
+	default:
+		return domain.BatchResult{}, fmt.Errorf("unknown batch action %q", batchOp.Action)
+	}
+}
@@ -0,0 +1,194 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"task-service/internal/lib/breaker"
+
+	"github.com/lib/pq"
+)
+
+// breakerConnector puts Postgres behind the circuit breaker: opening a
+// connection and every call on an open one. While Postgres is down, new and
+// pooled connections alike fail fast with breaker.ErrOpen instead of waiting
+// for a timeout each.
+//
+// Only failures to reach Postgres count. Errors Postgres answers with, such
+// as constraint violations, are successes.
+type breakerConnector struct {
+	driver.Connector
+	breaker *breaker.Breaker
+}
+
+func (c breakerConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := c.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	conn, err := c.Connector.Connect(ctx)
+	c.breaker.Record(failure(err))
+	if err != nil {
+		return nil, err
+	}
+	return &breakerConn{conn: conn, breaker: c.breaker}, nil
+}
+
+// breakerConn implements the optional interfaces of the pq connection, so
+// database/sql uses it the same way. Queries, statements, transactions and
+// pings are let through only by the breaker. Commit and rollback always are:
+// rejecting them would put the connection back into the pool inside a
+// transaction. Their failures still count, as do those of resetting a
+// session; errors while reading rows do not.
+type breakerConn struct {
+	conn    driver.Conn
+	breaker *breaker.Breaker
+}
+
+// call runs fn if the breaker allows it and records the outcome.
+func (c *breakerConn) call(fn func() error) error {
+	if err := c.breaker.Allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	c.breaker.Record(failure(err))
+	return err
+}
+
+// observe records the failure of a call that ran anyway.
+func (c *breakerConn) observe(err error) error {
+	if f := failure(err); f != nil {
+		c.breaker.Record(f)
+	}
+	return err
+}
+
+func (c *breakerConn) Prepare(query string) (stmt driver.Stmt, err error) {
+	err = c.call(func() error {
+		stmt, err = c.conn.Prepare(query)
+		return err
+	})
+	return stmt, err
+}
+
+func (c *breakerConn) PrepareContext(ctx context.Context, query string) (stmt driver.Stmt, err error) {
+	prepare, ok := c.conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+
+	err = c.call(func() error {
+		stmt, err = prepare.PrepareContext(ctx, query)
+		return err
+	})
+	return stmt, err
+}
+
+func (c *breakerConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *breakerConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *breakerConn) BeginTx(ctx context.Context, opts driver.TxOptions) (tx driver.Tx, err error) {
+	err = c.call(func() error {
+		if begin, ok := c.conn.(driver.ConnBeginTx); ok {
+			tx, err = begin.BeginTx(ctx, opts)
+		} else {
+			tx, err = c.conn.Begin()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return breakerTx{tx: tx, conn: c}, nil
+}
+
+func (c *breakerConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (result driver.Result, err error) {
+	exec, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	err = c.call(func() error {
+		result, err = exec.ExecContext(ctx, query, args)
+		return err
+	})
+	return result, err
+}
+
+func (c *breakerConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (rows driver.Rows, err error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	err = c.call(func() error {
+		rows, err = queryer.QueryContext(ctx, query, args)
+		return err
+	})
+	return rows, err
+}
+
+func (c *breakerConn) Ping(ctx context.Context) error {
+	pinger, ok := c.conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	return c.call(func() error {
+		return pinger.Ping(ctx)
+	})
+}
+
+func (c *breakerConn) ResetSession(ctx context.Context) error {
+	resetter, ok := c.conn.(driver.SessionResetter)
+	if !ok {
+		return nil
+	}
+	return c.observe(resetter.ResetSession(ctx))
+}
+
+func (c *breakerConn) IsValid() bool {
+	validator, ok := c.conn.(driver.Validator)
+	return !ok || validator.IsValid()
+}
+
+type breakerTx struct {
+	tx   driver.Tx
+	conn *breakerConn
+}
+
+func (t breakerTx) Commit() error {
+	return t.conn.observe(t.tx.Commit())
+}
+
+func (t breakerTx) Rollback() error {
+	return t.conn.observe(t.tx.Rollback())
+}
+
+// failure returns err if it means Postgres could not be reached or could not
+// serve the call, and nil otherwise. A caller giving up is not a failure.
+func failure(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, driver.ErrSkip) {
+		return nil
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Connection exceptions, insufficient resources and shutdowns.
+		if class := pqErr.Code.Class(); class == "08" || class == "53" {
+			return err
+		}
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03":
+			return err
+		}
+		return nil
+	}
+
+	return err
+}
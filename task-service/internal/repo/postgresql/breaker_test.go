@@ -0,0 +1,91 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"task-service/internal/lib/breaker"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// fakeConn answers every query with err and counts the calls that reached it.
+type fakeConn struct {
+	err       error
+	calls     int
+	rollbacks int
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, c.err }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { c.calls++; return c, c.err }
+func (c *fakeConn) Commit() error                       { return c.err }
+func (c *fakeConn) Rollback() error                     { c.rollbacks++; return c.err }
+
+func (c *fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	c.calls++
+	return nil, c.err
+}
+
+func newBreakerConn(err error) (*breakerConn, *fakeConn) {
+	fake := &fakeConn{err: err}
+	b := breaker.New(slog.New(slog.NewTextHandler(io.Discard, nil)), "postgres", 2, time.Hour)
+	return &breakerConn{conn: fake, breaker: b}, fake
+}
+
+func TestBreakerConnOpensOnQueryFailures(t *testing.T) {
+	conn, fake := newBreakerConn(driver.ErrBadConn)
+
+	for range 2 {
+		if _, err := conn.ExecContext(context.Background(), "SELECT 1", nil); !errors.Is(err, driver.ErrBadConn) {
+			t.Fatalf("ExecContext() error = %v, want %v", err, driver.ErrBadConn)
+		}
+	}
+	if state := conn.breaker.State(); state != breaker.Open {
+		t.Fatalf("breaker is %s after 2 failed queries, want open", state)
+	}
+
+	if _, err := conn.ExecContext(context.Background(), "SELECT 1", nil); !errors.Is(err, breaker.ErrOpen) {
+		t.Errorf("ExecContext() error = %v, want %v", err, breaker.ErrOpen)
+	}
+	if fake.calls != 2 {
+		t.Errorf("%d queries reached Postgres, want 2", fake.calls)
+	}
+}
+
+func TestBreakerConnIgnoresErrorsOfPostgres(t *testing.T) {
+	conn, _ := newBreakerConn(&pq.Error{Code: "23505"})
+
+	for range 3 {
+		conn.ExecContext(context.Background(), "INSERT", nil)
+	}
+	if state := conn.breaker.State(); state != breaker.Closed {
+		t.Errorf("breaker is %s after unique violations, want closed", state)
+	}
+}
+
+func TestBreakerConnRollsBackWhileOpen(t *testing.T) {
+	conn, fake := newBreakerConn(nil)
+
+	tx, err := conn.BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+
+	fake.err = io.ErrUnexpectedEOF
+	for range 2 {
+		conn.ExecContext(context.Background(), "UPDATE", nil)
+	}
+	if state := conn.breaker.State(); state != breaker.Open {
+		t.Fatalf("breaker is %s, want open", state)
+	}
+
+	tx.Rollback()
+	if fake.rollbacks != 1 {
+		t.Errorf("rollback reached Postgres %d times while the breaker is open, want 1", fake.rollbacks)
+	}
+}
@@ -0,0 +1,197 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+// AddBlocker records that the task cannot start until blockerId is done.
+// Adding an existing dependency is a no-op. Dependencies that would close a
+// cycle are rejected; changes to the dependency graph of one owner are
+// serialized so two concurrent inserts cannot form a cycle together.
+func (r *Repository) AddBlocker(ownerId, id, blockerId uuid.UUID) error {
+	const op = "repo.postgresql.AddBlocker"
+
+	if id == blockerId {
+		return fmt.Errorf("%s: %w", op, domain.ErrDependencyCycle)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	if err = lockOwner(tx, "task-dependencies", ownerId); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to lock dependencies: %w", op, err)
+	}
+
+	var tasks, blockers int
+	query := `
+		SELECT count(*) FILTER (WHERE id = $1), count(*) FILTER (WHERE id = $2)
+		FROM tasks
+		WHERE id IN ($1, $2) AND owner_id = $3 AND deleted_at IS NULL
+	`
+	if err = tx.QueryRow(query, id, blockerId, ownerId).Scan(&tasks, &blockers); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to get tasks: %w", op, err)
+	}
+
+	if tasks == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, domain.ErrTaskNotFound)
+	}
+	if blockers == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, domain.ErrBlockerNotFound)
+	}
+
+	// The new edge closes a cycle if the blocker already waits for the task,
+	// directly or through other tasks.
+	query = `
+		WITH RECURSIVE chain(id) AS (
+			SELECT blocker_id FROM task_dependencies WHERE task_id = $1
+			UNION
+			SELECT d.blocker_id FROM task_dependencies d JOIN chain c ON d.task_id = c.id
+		)
+		SELECT EXISTS (SELECT 1 FROM chain WHERE id = $2)
+	`
+
+	var cycle bool
+	if err = tx.QueryRow(query, blockerId, id).Scan(&cycle); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to check dependency cycle: %w", op, err)
+	}
+
+	if cycle {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", op, domain.ErrDependencyCycle)
+	}
+
+	query = `
+		INSERT INTO task_dependencies (task_id, blocker_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err = tx.Exec(query, id, blockerId); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: failed to add dependency: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveBlocker(ownerId, id, blockerId uuid.UUID) error {
+	const op = "repo.postgresql.RemoveBlocker"
+
+	query := `
+		DELETE FROM task_dependencies d
+		USING tasks t
+		WHERE d.task_id = $1 AND d.blocker_id = $2 AND t.id = d.task_id AND t.owner_id = $3 AND t.deleted_at IS NULL
+	`
+
+	result, err := r.db.Exec(query, id, blockerId, ownerId)
+	if err != nil {
+		return fmt.Errorf("%s: failed to remove dependency: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to remove dependency: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrDependencyNotFound)
+	}
+
+	return nil
+}
+
+// unfinishedBlockers returns the blockers of the task that are not DONE,
+// locked against changes until the transaction ends.
+func unfinishedBlockers(tx *sql.Tx, ownerId, id uuid.UUID) ([]domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		JOIN (
+			SELECT blocker_id AS id, created_at AS added_at
+			FROM task_dependencies
+			WHERE task_id = $1
+		) d USING (id)
+		WHERE owner_id = $2 AND deleted_at IS NULL AND status <> 'DONE'
+		ORDER BY d.added_at, id
+		FOR SHARE OF tasks
+	`
+
+	rows, err := tx.Query(query, id, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// GetBlockers returns the tasks the task waits for, oldest dependency first.
+// With unfinished only the blockers that are not DONE yet are returned.
+func (r *Repository) GetBlockers(ownerId, id uuid.UUID, unfinished bool) ([]domain.Task, error) {
+	const op = "repo.postgresql.GetBlockers"
+
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL)`, id, ownerId).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get task: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrTaskNotFound)
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		JOIN (
+			SELECT blocker_id AS id, created_at AS added_at
+			FROM task_dependencies
+			WHERE task_id = $1
+		) d USING (id)
+		WHERE owner_id = $2 AND deleted_at IS NULL AND (NOT $3 OR status <> 'DONE')
+		ORDER BY d.added_at, id
+	`
+
+	rows, err := r.db.Query(query, id, ownerId, unfinished)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get blockers: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get blockers: %w", op, err)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,262 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// checkParent makes sure parentId is a task of the owner and that id is not
+// among its ancestors, so hanging id under it does not close a cycle. Changes
+// to the hierarchy of one owner are serialized for the rest of the
+// transaction: two concurrent moves could otherwise each pass the check and
+// still form a cycle together.
+func checkParent(tx *sql.Tx, ownerId, id, parentId uuid.UUID) error {
+	if err := lockOwner(tx, "task-hierarchy", ownerId); err != nil {
+		return fmt.Errorf("failed to lock task hierarchy: %w", err)
+	}
+
+	query := `
+		WITH RECURSIVE ancestors(id, parent_id) AS (
+			SELECT id, parent_id FROM tasks WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+		)
+		SELECT count(*), count(*) FILTER (WHERE id = $3) FROM ancestors
+	`
+
+	var found, cycles int
+	if err := tx.QueryRow(query, parentId, ownerId, id).Scan(&found, &cycles); err != nil {
+		return fmt.Errorf("failed to check parent: %w", err)
+	}
+
+	if found == 0 {
+		return domain.ErrParentNotFound
+	}
+	if cycles > 0 {
+		return domain.ErrTaskCycle
+	}
+
+	return nil
+}
+
+// SetTaskParent makes the task a subtask of parentId, or a top level task
+// when parentId is nil.
+func (r *Repository) SetTaskParent(ctx context.Context, ownerId, id uuid.UUID, parentId *uuid.UUID) error {
+	const op = "repo.postgresql.SetTaskParent"
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		if parentId != nil {
+			if err := checkParent(tx, ownerId, id, *parentId); err != nil {
+				return err
+			}
+		}
+
+		before, err := lockTask(tx, ownerId, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(`UPDATE tasks SET parent_id = $1, version = version + 1 WHERE id = $2`, parentId, id); err != nil {
+			return fmt.Errorf("failed to set parent: %w", err)
+		}
+
+		after, err := getTask(tx, ownerId, id)
+		if err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditUpdate, ownerId.String(), &before, after)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetTaskChildren returns the direct subtasks of a task, oldest first.
+func (r *Repository) GetTaskChildren(ownerId, id uuid.UUID) ([]domain.Task, error) {
+	const op = "repo.postgresql.GetTaskChildren"
+
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL)`, id, ownerId).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get task: %w", op, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrTaskNotFound)
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE parent_id = $1 AND owner_id = $2 AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.Query(query, id, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get children: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get children: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// GetTaskSubtree returns the task followed by all of its subtasks at every
+// depth. Parents always come before their children.
+func (r *Repository) GetTaskSubtree(ownerId, id uuid.UUID) ([]domain.Task, error) {
+	const op = "repo.postgresql.GetTaskSubtree"
+
+	query := `
+		WITH RECURSIVE subtree(id, depth) AS (
+			SELECT id, 0 FROM tasks WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, s.depth + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT ` + taskColumns + `
+		FROM tasks JOIN subtree USING (id)
+		ORDER BY subtree.depth, created_at, id
+	`
+
+	rows, err := r.db.Query(query, id, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get subtree: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get subtree: %w", op, err)
+	}
+
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, domain.ErrTaskNotFound)
+	}
+
+	return tasks, nil
+}
+
+// GetTaskProgress counts the subtasks of a task at every depth and how many
+// of them are done.
+func (r *Repository) GetTaskProgress(ownerId, id uuid.UUID) (domain.Progress, error) {
+	const op = "repo.postgresql.GetTaskProgress"
+
+	query := `
+		WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE parent_id = $1 AND owner_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT count(*), count(*) FILTER (WHERE status = 'DONE')
+		FROM tasks JOIN subtree USING (id)
+	`
+
+	var progress domain.Progress
+	if err := r.db.QueryRow(query, id, ownerId).Scan(&progress.Total, &progress.Done); err != nil {
+		return domain.Progress{}, fmt.Errorf("%s: failed to get progress: %w", op, err)
+	}
+
+	return progress, nil
+}
+
+// GetTasksProgress is GetTaskProgress for several tasks of the owner at
+// once. Tasks without subtasks have no progress in the map.
+func (r *Repository) GetTasksProgress(ownerId uuid.UUID, ids ...uuid.UUID) (map[uuid.UUID]domain.Progress, error) {
+	const op = "repo.postgresql.GetTasksProgress"
+
+	query := `
+		WITH RECURSIVE subtree(root, id) AS (
+			SELECT parent_id, id FROM tasks WHERE parent_id = ANY($1) AND owner_id = $2 AND deleted_at IS NULL
+			UNION ALL
+			SELECT s.root, t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT s.root, count(*), count(*) FILTER (WHERE t.status = 'DONE')
+		FROM subtree s JOIN tasks t USING (id)
+		GROUP BY s.root
+	`
+
+	rows, err := r.db.Query(query, pq.Array(ids), ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get progress: %w", op, err)
+	}
+	defer rows.Close()
+
+	progress := make(map[uuid.UUID]domain.Progress)
+	for rows.Next() {
+		var id uuid.UUID
+		var p domain.Progress
+		if err := rows.Scan(&id, &p.Total, &p.Done); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan progress: %w", op, err)
+		}
+		progress[id] = p
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get progress: %w", op, err)
+	}
+
+	return progress, nil
+}
+
+// GetTaskAncestors returns the parents of the tasks up to the top level,
+// each once. Their progress changes with the tasks.
+func (r *Repository) GetTaskAncestors(ownerId uuid.UUID, ids ...uuid.UUID) ([]uuid.UUID, error) {
+	const op = "repo.postgresql.GetTaskAncestors"
+
+	query := `
+		WITH RECURSIVE ancestors(id) AS (
+			SELECT parent_id FROM tasks WHERE id = ANY($1) AND owner_id = $2 AND parent_id IS NOT NULL
+			UNION
+			SELECT t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.id WHERE t.parent_id IS NOT NULL
+		)
+		SELECT id FROM ancestors
+	`
+
+	rows, err := r.db.Query(query, pq.Array(ids), ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get ancestors: %w", op, err)
+	}
+	defer rows.Close()
+
+	var ancestors []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan ancestor: %w", op, err)
+		}
+		ancestors = append(ancestors, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get ancestors: %w", op, err)
+	}
+
+	return ancestors, nil
+}
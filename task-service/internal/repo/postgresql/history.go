@@ -0,0 +1,60 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+func insertStatusChange(tx *sql.Tx, change domain.StatusChange) error {
+	query := `
+		INSERT INTO task_status_history (task_id, from_status, to_status, actor)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := tx.Exec(query, change.TaskId, change.From, change.To, change.Actor)
+	return err
+}
+
+// GetStatusHistory returns the status transitions of a task, oldest first.
+func (r *Repository) GetStatusHistory(ownerId, id uuid.UUID) ([]domain.StatusChange, error) {
+	const op = "repo.postgresql.GetStatusHistory"
+
+	query := `
+		SELECT h.task_id, h.from_status, h.to_status, h.actor, h.changed_at
+		FROM task_status_history h
+		JOIN tasks t ON t.id = h.task_id
+		WHERE h.task_id = $1 AND t.owner_id = $2 AND t.deleted_at IS NULL
+		ORDER BY h.changed_at, h.id
+	`
+
+	rows, err := r.db.Query(query, id, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get status history: %w", op, err)
+	}
+	defer rows.Close()
+
+	var changes []domain.StatusChange
+	for rows.Next() {
+		var change domain.StatusChange
+		err := rows.Scan(
+			&change.TaskId,
+			&change.From,
+			&change.To,
+			&change.Actor,
+			&change.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan status change: %w", op, err)
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get status history: %w", op, err)
+	}
+
+	return changes, nil
+}
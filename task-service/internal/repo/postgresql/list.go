@@ -0,0 +1,260 @@
+package postgresql
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"task-service/domain"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultListLimit = 50
+	defaultSortBy    = "priority"
+)
+
+type sortKey struct {
+	column string
+	value  func(task domain.Task) string
+}
+
+var sortKeys = map[string]sortKey{
+	"id": {"id", func(t domain.Task) string {
+		return t.Id.String()
+	}},
+	"title": {"title", func(t domain.Task) string {
+		return t.Title
+	}},
+	"description": {"description", func(t domain.Task) string {
+		return t.Description
+	}},
+	"task_status": {"status", func(t domain.Task) string {
+		return string(t.TaskStatus)
+	}},
+	"priority": {"priority", func(t domain.Task) string {
+		return string(t.Priority)
+	}},
+	"created_at": {"created_at", func(t domain.Task) string {
+		return t.CreatedAt.Format(time.RFC3339Nano)
+	}},
+	"repeat_task": {"repeatable", func(t domain.Task) string {
+		return string(t.RepeatTask)
+	}},
+	"start_at": {"COALESCE(start_at, 'infinity')", func(t domain.Task) string {
+		return formatOptionalTime(t.StartAt)
+	}},
+	"due_at": {"COALESCE(due_at, 'infinity')", func(t domain.Task) string {
+		return formatOptionalTime(t.DueAt)
+	}},
+}
+
+// formatOptionalTime mirrors the COALESCE used for nullable sort columns:
+// tasks without a date sort after all dated ones.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "infinity"
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// keysetFor returns the columns the page is ordered by. The requested column
+// always comes first, ties are broken by (created_at, id) so that the order
+// is total and a cursor points at exactly one row. Tasks of the same priority
+// are ordered by due date first.
+func keysetFor(sortBy string) ([]sortKey, error) {
+	if sortBy == "" {
+		sortBy = defaultSortBy
+	}
+
+	key, ok := sortKeys[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort column %q", sortBy)
+	}
+
+	keys := []sortKey{key}
+	if sortBy == "priority" {
+		keys = append(keys, sortKeys["due_at"])
+	}
+	if sortBy != "created_at" && sortBy != "id" {
+		keys = append(keys, sortKeys["created_at"])
+	}
+	if sortBy != "id" {
+		keys = append(keys, sortKeys["id"])
+	}
+
+	return keys, nil
+}
+
+func encodeCursor(keys []sortKey, task domain.Task) string {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = key.value(task)
+	}
+
+	raw, _ := json.Marshal(values)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor string, keys []sortKey) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var values []string
+	if err := json.Unmarshal(raw, &values); err != nil || len(values) != len(keys) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return values, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func (r *Repository) ListTasks(filter domain.TaskFilter) (domain.TaskPage, error) {
+	const op = "repo.postgresql.ListTasks"
+
+	keys, err := keysetFor(filter.SortBy)
+	if err != nil {
+		return domain.TaskPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	order := filter.Order
+	if order == "" {
+		order = domain.ASC
+		if filter.SortBy == "created_at" {
+			order = domain.DESC
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where = append(where, "owner_id = "+arg(filter.OwnerId), "deleted_at IS NULL")
+
+	if filter.ProjectId != nil {
+		where = append(where, "project_id = "+arg(*filter.ProjectId))
+	}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = string(s)
+		}
+		where = append(where, "status = ANY("+arg(pq.Array(statuses))+"::task_status[])")
+	}
+
+	if len(filter.Priorities) > 0 {
+		priorities := make([]string, len(filter.Priorities))
+		for i, p := range filter.Priorities {
+			priorities[i] = string(p)
+		}
+		where = append(where, "priority = ANY("+arg(pq.Array(priorities))+"::task_priority[])")
+	}
+
+	if len(filter.RepeatTypes) > 0 {
+		repeats := make([]string, len(filter.RepeatTypes))
+		for i, rt := range filter.RepeatTypes {
+			repeats[i] = string(rt)
+		}
+		where = append(where, "repeatable = ANY("+arg(pq.Array(repeats))+"::repeatable_task[])")
+	}
+
+	if len(filter.Tags) > 0 {
+		tagged := "SELECT count(DISTINCT g.name) FROM task_tags tt JOIN tags g ON g.id = tt.tag_id" +
+			" WHERE tt.task_id = tasks.id AND g.name = ANY(" + arg(pq.Array(filter.Tags)) + ")"
+		if filter.TagMode == domain.TagsAny {
+			where = append(where, "("+tagged+") > 0")
+		} else {
+			where = append(where, "("+tagged+") = "+arg(len(filter.Tags)))
+		}
+	}
+
+	if filter.CreatedAfter != nil {
+		where = append(where, "created_at >= "+arg(*filter.CreatedAfter))
+	}
+
+	if filter.CreatedBefore != nil {
+		where = append(where, "created_at < "+arg(*filter.CreatedBefore))
+	}
+
+	if filter.Title != "" {
+		where = append(where, "title ILIKE '%' || "+arg(escapeLike(filter.Title))+" || '%'")
+	}
+
+	if filter.Cursor != "" {
+		values, err := decodeCursor(filter.Cursor, keys)
+		if err != nil {
+			return domain.TaskPage{}, fmt.Errorf("%s: %w", op, err)
+		}
+
+		cmp := ">"
+		if order == domain.DESC {
+			cmp = "<"
+		}
+
+		// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+		var alternatives []string
+		for i, key := range keys {
+			var parts []string
+			for j := 0; j < i; j++ {
+				parts = append(parts, keys[j].column+" = "+arg(values[j]))
+			}
+			parts = append(parts, key.column+" "+cmp+" "+arg(values[i]))
+			alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+		}
+		where = append(where, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	orderBy := make([]string, len(keys))
+	for i, key := range keys {
+		orderBy[i] = key.column + " " + string(order)
+	}
+
+	query := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(where, " AND ")
+	query += " ORDER BY " + strings.Join(orderBy, ", ")
+	query += " LIMIT " + arg(limit+1)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return domain.TaskPage{}, fmt.Errorf("%s: failed to list tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.Task, 0, limit)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return domain.TaskPage{}, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return domain.TaskPage{}, fmt.Errorf("%s: failed to list tasks: %w", op, err)
+	}
+
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = encodeCursor(keys, page.Tasks[limit-1])
+	}
+
+	return page, nil
+}
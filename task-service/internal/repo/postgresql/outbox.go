@@ -0,0 +1,219 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"task-service/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// outboxLock is the advisory lock key held by the relay so only one instance
+// publishes at a time.
+const outboxLock = 7_402_118
+
+// enqueueEvent queues an event in the outbox and for every subscribed webhook
+// of the owner in the transaction of the change it describes, so the event is
+// published if and only if the change commits.
+func enqueueEvent(tx *sql.Tx, eventType domain.EventType, event domain.TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var id int64
+	query := `INSERT INTO outbox (event_type, task_id, owner_id, payload) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	if err = tx.QueryRow(query, eventType, event.Id, event.OwnerId, payload).Scan(&id); err != nil {
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
+
+	return enqueueDeliveries(tx, event.OwnerId, id, eventType, payload)
+}
+
+// RelayEvents hands up to limit unpublished events to publish in the order
+// of their ids and marks the ones it accepted as published. It stops at the
+// first event publish fails on, so that event and everything after it is
+// retried on the next call.
+//
+// Ids are taken when an event is queued, not when its transaction commits,
+// so a missing id may belong to a transaction that has not committed yet.
+// Events after such a gap are held back until the gap is filled, or until
+// the event after it was queued gapTimeout ago: the gap is then taken for a
+// rolled back transaction. Only an event committed later than that is
+// published out of order. Clients can therefore resume after the id of the
+// last event they received.
+//
+// An event is published again if the transaction fails to commit after
+// publish succeeded: delivery is at least once.
+//
+// RelayEvents returns 0 without publishing when another relay holds the lock.
+func (r *Repository) RelayEvents(ctx context.Context, limit int, gapTimeout time.Duration, publish func(domain.Event) error) (int, error) {
+	const op = "repo.postgresql.RelayEvents"
+
+	relayed := 0
+	var publishErr error
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var locked bool
+		if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, outboxLock).Scan(&locked); err != nil {
+			return fmt.Errorf("failed to lock outbox: %w", err)
+		}
+		if !locked {
+			return nil
+		}
+
+		var last int64
+		if err := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM outbox WHERE published_at IS NOT NULL`).Scan(&last); err != nil {
+			return fmt.Errorf("failed to read outbox: %w", err)
+		}
+
+		events, err := pendingEvents(tx, limit, gapTimeout)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int64, 0, len(events))
+		for _, event := range events {
+			if event.Id != last+1 && !event.settled {
+				break
+			}
+			if publishErr = publish(event.Event); publishErr != nil {
+				break
+			}
+			ids = append(ids, event.Id)
+			last = event.Id
+		}
+
+		if len(ids) > 0 {
+			query := `UPDATE outbox SET published_at = NOW() WHERE id = ANY($1)`
+			if _, err = tx.Exec(query, pq.Array(ids)); err != nil {
+				return fmt.Errorf("failed to mark events published: %w", err)
+			}
+		}
+		relayed = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if publishErr != nil {
+		return relayed, fmt.Errorf("%s: failed to publish event: %w", op, publishErr)
+	}
+
+	return relayed, nil
+}
+
+// pendingEvent is an unpublished event. It is settled once it was queued
+// long enough ago for a gap before it to be given up on.
+type pendingEvent struct {
+	domain.Event
+	settled bool
+}
+
+func pendingEvents(tx *sql.Tx, limit int, gapTimeout time.Duration) ([]pendingEvent, error) {
+	query := `
+		SELECT ` + eventColumns + `, queued_at <= clock_timestamp() - make_interval(secs => $2)
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`
+
+	rows, err := tx.Query(query, limit, gapTimeout.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var events []pendingEvent
+	for rows.Next() {
+		var event pendingEvent
+		err := rows.Scan(&event.Id, &event.Type, &event.TaskId, &event.OwnerId, &event.Payload, &event.CreatedAt, &event.settled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	return events, nil
+}
+
+const eventColumns = `id, event_type, task_id, owner_id, payload, created_at`
+
+func scanEvents(rows *sql.Rows) ([]domain.Event, error) {
+	var events []domain.Event
+	for rows.Next() {
+		var event domain.Event
+		err := rows.Scan(&event.Id, &event.Type, &event.TaskId, &event.OwnerId, &event.Payload, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %w", err)
+	}
+
+	return events, nil
+}
+
+// ListEvents returns up to limit published events of the owner queued after
+// the event with the given id, oldest first. Events older than the outbox
+// retention are gone.
+func (r *Repository) ListEvents(ownerId uuid.UUID, after int64, limit int) ([]domain.Event, error) {
+	const op = "repo.postgresql.ListEvents"
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM outbox
+		WHERE owner_id = $1 AND id > $2 AND published_at IS NOT NULL
+		ORDER BY id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, ownerId, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list events: %w", op, err)
+	}
+	defer rows.Close()
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
+
+// PurgePublishedEvents removes events published before the given time. The
+// last published event is kept: RelayEvents looks for gaps after it.
+func (r *Repository) PurgePublishedEvents(before time.Time) (int, error) {
+	const op = "repo.postgresql.PurgePublishedEvents"
+
+	query := `
+		DELETE FROM outbox
+		WHERE published_at < $1
+			AND id < (SELECT MAX(id) FROM outbox WHERE published_at IS NOT NULL)
+	`
+
+	result, err := r.db.Exec(query, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to purge events: %w", op, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to purge events: %w", op, err)
+	}
+
+	return int(purged), nil
+}
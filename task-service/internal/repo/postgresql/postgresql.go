@@ -1,153 +1,591 @@
-package postgresql
-
-import (
-	"database/sql"
-	"fmt"
-	"task-service/domain"
-
-	"github.com/google/uuid"
-	_ "github.com/lib/pq"
-)
-
-type Repository struct {
-	db *sql.DB
-}
-
-func NewDb(config string) (*Repository, error) {
-	db, err := sql.Open("postgres", config)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err = db.Ping(); err != nil {
-		return nil, err
-	}
-
-	return &Repository{db: db}, nil
-}
-
-func (r *Repository) SaveTask(entity domain.Task) error {
-	const op = "repo.postgresql.Save"
-
-	tx, err := r.db.Begin()
-	if err != nil {
-		return fmt.Errorf("Failed to begin transaction: %w", err)
-	}
-
-	query := `
-        INSERT INTO tasks (id, title, description, status, created_at, repeatable)
-        VALUES ($1, $2, $3, $4, $5, $6)
-    `
-
-	_, err = tx.Exec(query,
-		entity.Id,
-		entity.Title,
-		entity.Description,
-		entity.TaskStatus,
-		entity.CreatedAt,
-		entity.RepeatTask,
-	)
-
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("%s: failed to save task: %w", op, err)
-	}
-
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
-	}
-
-	return nil
-}
-
-func (r *Repository) DeleteTaskById(id uuid.UUID) error {
-	const op = "repo.postgresql.DeleteTaskById"
-
-	tx, err := r.db.Begin()
-	if err != nil {
-		return fmt.Errorf("%s: Failed to begin transaction: %w", op, err)
-	}
-
-	query := `DELETE FROM tasks WHERE id = $1`
-	_, err = tx.Exec(query, id)
-
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("%s: failed to delete task: %w", op, err)
-	}
-
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("%s: failed to commit transaction: %w", op, err)
-	}
-
-	return nil
-}
-
-func (r *Repository) GetTaskById(id uuid.UUID) (domain.Task, error) {
-	const op = "repo.postgresql.GetTaskById"
-
-	query := `
-		SELECT id, title, description, status, created_at, repeatable
-		FROM tasks
-		WHERE id = $1
-	`
-
-	row := r.db.QueryRow(query, id)
-
-	var task domain.Task
-	err := row.Scan(
-		&task.Id,
-		&task.Title,
-		&task.Description,
-		&task.TaskStatus,
-		&task.CreatedAt,
-		&task.RepeatTask,
-	)
-
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return domain.Task{}, fmt.Errorf("%s: task not found: %w", op, err)
-		}
-		return domain.Task{}, fmt.Errorf("%s: failed to get task by id: %w", op, err)
-	}
-
-	return task, nil
-}
-
-func (r *Repository) UpdateTaskById(id uuid.UUID, updates domain.Task) error {
-	const op = "repo.postgresql.UpdateTaskById"
-
-	query := `
-        UPDATE tasks
-        SET 
-            title = COALESCE($1, title),
-            description = COALESCE($2, description),
-            status = COALESCE($3, status),
-            repeatable = COALESCE($4, repeatable)
-        WHERE id = $5
-    `
-
-	result, err := r.db.Exec(query,
-		sql.NullString{String: updates.Title, Valid: updates.Title != ""},
-		sql.NullString{String: updates.Description, Valid: updates.Description != ""},
-		sql.NullString{String: string(updates.TaskStatus), Valid: updates.TaskStatus != ""},
-		sql.NullString{String: string(updates.RepeatTask), Valid: updates.RepeatTask != ""},
-		id,
-	)
-
-	if err != nil {
-		return fmt.Errorf("%s: failed to update task: %w", op, err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("%s: failed to update task: %w", op, err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("%s: task with id %s not found", op, id)
-	}
-
-	return nil
-}
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"task-service/domain"
+	"task-service/internal/config"
+	"task-service/internal/lib/breaker"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	db      *sql.DB
+	breaker *breaker.Breaker
+}
+
+// taskColumns must be selected from the tasks table under its own name: the
+// tag names are read by a subquery correlated on tasks.id.
+const taskColumns = "id, title, description, status, created_at, repeatable, series_id, series_start, occurrence, start_at, due_at, owner_id, project_id, parent_id, priority, version, deleted_at, COALESCE(timezone, ''), " +
+	"ARRAY(SELECT g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = tasks.id ORDER BY g.name)"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads the columns listed in taskColumns, followed by any extra
+// columns the query selects after them.
+func scanTask(row rowScanner, extra ...any) (domain.Task, error) {
+	var task domain.Task
+	dest := append([]any{
+		&task.Id,
+		&task.Title,
+		&task.Description,
+		&task.TaskStatus,
+		&task.CreatedAt,
+		&task.RepeatTask,
+		&task.SeriesId,
+		&task.SeriesStart,
+		&task.Occurrence,
+		&task.StartAt,
+		&task.DueAt,
+		&task.OwnerId,
+		&task.ProjectId,
+		&task.ParentId,
+		&task.Priority,
+		&task.Version,
+		&task.DeletedAt,
+		&task.Timezone,
+		(*pq.StringArray)(&task.Tags),
+	}, extra...)
+	err := row.Scan(dest...)
+	return task, err
+}
+
+func NewDb(log *slog.Logger, url string, cfg config.Breaker) (*Repository, error) {
+	connector, err := pq.NewConnector(url)
+	if err != nil {
+		return nil, err
+	}
+
+	b := breaker.New(log, "postgres", cfg.Threshold, cfg.Timeout)
+	db := sql.OpenDB(breakerConnector{Connector: connector, breaker: b})
+
+	if err = db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return &Repository{db: db, breaker: b}, nil
+}
+
+func (r *Repository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// Close closes the connections. Calls made after it fail.
+func (r *Repository) Close() error {
+	return r.db.Close()
+}
+
+// State is the state of the circuit breaker in front of Postgres.
+func (r *Repository) State() breaker.State {
+	return r.breaker.State()
+}
+
+// inTx runs fn in a transaction and commits it if fn succeeds.
+func (r *Repository) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) SaveTask(ctx context.Context, entity domain.Task) error {
+	const op = "repo.postgresql.Save"
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		return saveTask(ctx, tx, entity)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func saveTask(ctx context.Context, tx *sql.Tx, entity domain.Task) error {
+	if entity.ProjectId != nil {
+		if err := lockProject(tx, entity.OwnerId, *entity.ProjectId); err != nil {
+			return err
+		}
+	}
+
+	if entity.ParentId != nil {
+		if err := checkParent(tx, entity.OwnerId, entity.Id, *entity.ParentId); err != nil {
+			return err
+		}
+	}
+
+	if err := insertTask(tx, entity); err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+
+	task, err := getTask(tx, entity.OwnerId, entity.Id)
+	if err != nil {
+		return err
+	}
+
+	return recordChange(ctx, tx, domain.AuditCreate, entity.OwnerId.String(), nil, task)
+}
+
+func insertTask(tx *sql.Tx, entity domain.Task) error {
+	query := `
+        INSERT INTO tasks (id, title, description, status, created_at, repeatable, series_id, series_start, occurrence, start_at, due_at, owner_id, project_id, parent_id, priority, timezone)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+    `
+
+	_, err := tx.Exec(query,
+		entity.Id,
+		entity.Title,
+		entity.Description,
+		entity.TaskStatus,
+		entity.CreatedAt,
+		entity.RepeatTask,
+		entity.SeriesId,
+		entity.SeriesStart,
+		entity.Occurrence,
+		entity.StartAt,
+		entity.DueAt,
+		uuid.NullUUID{UUID: entity.OwnerId, Valid: entity.OwnerId != uuid.Nil},
+		entity.ProjectId,
+		entity.ParentId,
+		entity.Priority,
+		sql.NullString{String: entity.Timezone, Valid: entity.Timezone != ""},
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(entity.Tags) > 0 {
+		return setTaskTags(tx, entity.OwnerId, entity.Id, entity.Tags)
+	}
+
+	return nil
+}
+
+// DeleteTaskById moves the task to the trash and returns the ids of every
+// deleted task. With cascade the whole subtree goes, otherwise the subtasks
+// are moved up to the parent of the deleted task. A non-zero version must
+// match the current version of the task.
+func (r *Repository) DeleteTaskById(ctx context.Context, ownerId, id uuid.UUID, cascade bool, version int64) ([]uuid.UUID, error) {
+	const op = "repo.postgresql.DeleteTaskById"
+
+	var deleted []uuid.UUID
+	err := r.inTx(ctx, func(tx *sql.Tx) (err error) {
+		deleted, err = deleteTask(ctx, tx, ownerId, id, cascade, version)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deleted, nil
+}
+
+// deleteTask stamps the task and its deleted subtasks with the same
+// deleted_at, which is how RestoreTask finds what was deleted together.
+func deleteTask(ctx context.Context, tx *sql.Tx, ownerId, id uuid.UUID, cascade bool, version int64) ([]uuid.UUID, error) {
+	current, err := lockTask(tx, ownerId, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && current.Version != version {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	if !cascade {
+		children, err := taskIds(tx, `SELECT id FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL`, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subtasks: %w", err)
+		}
+
+		before, err := lockTasks(tx, children)
+		if err != nil {
+			return nil, err
+		}
+
+		query := `
+			UPDATE tasks
+			SET parent_id = $2, version = version + 1
+			WHERE id = ANY($1)
+		`
+		if _, err := tx.Exec(query, pq.Array(children), current.ParentId); err != nil {
+			return nil, fmt.Errorf("failed to lift subtasks: %w", err)
+		}
+
+		if err := recordChanges(ctx, tx, domain.AuditUpdate, ownerId.String(), before); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		WITH RECURSIVE subtree(id) AS (
+			SELECT id FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at IS NULL
+		)
+		SELECT id FROM subtree
+	`
+
+	deleted, err := taskIds(tx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subtree: %w", err)
+	}
+
+	before, err := lockTasks(tx, deleted)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		UPDATE tasks
+		SET deleted_at = $2, version = version + 1
+		WHERE id = ANY($1)
+	`
+	if _, err := tx.Exec(query, pq.Array(deleted), time.Now().UTC()); err != nil {
+		return nil, fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	if err := recordChanges(ctx, tx, domain.AuditDelete, ownerId.String(), before); err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+// lockTask returns the task locked for the rest of the transaction, or
+// domain.ErrTaskNotFound if the owner has no such task outside the trash.
+func lockTask(tx *sql.Tx, ownerId, id uuid.UUID) (domain.Task, error) {
+	locked, err := lockTasks(tx, []uuid.UUID{id})
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task, ok := locked[id]
+	if !ok || task.OwnerId != ownerId || task.DeletedAt != nil {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+func taskIds(tx *sql.Tx, query string, args ...any) ([]uuid.UUID, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func getTask(tx *sql.Tx, ownerId, id uuid.UUID) (domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+	`
+
+	task, err := scanTask(tx.QueryRow(query, id, ownerId))
+	if err == sql.ErrNoRows {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return task, nil
+}
+
+func (r *Repository) GetTaskById(ownerId, id uuid.UUID) (domain.Task, error) {
+	const op = "repo.postgresql.GetTaskById"
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NULL
+	`
+
+	task, err := scanTask(r.db.QueryRow(query, id, ownerId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Task{}, fmt.Errorf("%s: %w", op, domain.ErrTaskNotFound)
+		}
+		return domain.Task{}, fmt.Errorf("%s: failed to get task by id: %w", op, err)
+	}
+
+	return task, nil
+}
+
+// GetOverdueTasks returns unfinished tasks whose due date is before now,
+// the most overdue first.
+func (r *Repository) GetOverdueTasks(ownerId uuid.UUID, now time.Time, limit int) ([]domain.Task, error) {
+	const op = "repo.postgresql.GetOverdueTasks"
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE owner_id = $1 AND due_at < $2 AND status <> 'DONE' AND deleted_at IS NULL
+		ORDER BY due_at, id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, ownerId, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get overdue tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.Task, 0, limit)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get overdue tasks: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+func (r *Repository) SearchTasks(ownerId uuid.UUID, text string, limit int) ([]domain.SearchResult, error) {
+	const op = "repo.postgresql.SearchTasks"
+
+	query := `
+		SELECT ` + taskColumns + `,
+			ts_rank(search_vector, q) AS rank,
+			ts_headline('simple', coalesce(title, ''), q, 'StartSel=<b>, StopSel=</b>, HighlightAll=true'),
+			ts_headline('simple', coalesce(description, ''), q, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM tasks, websearch_to_tsquery('simple', $1) q
+		WHERE owner_id = $2 AND search_vector @@ q AND deleted_at IS NULL
+		ORDER BY rank DESC, created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(query, text, ownerId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to search tasks: %w", op, err)
+	}
+	defer rows.Close()
+
+	results := make([]domain.SearchResult, 0, limit)
+	for rows.Next() {
+		var result domain.SearchResult
+		result.Task, err = scanTask(rows,
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.DescriptionHighlight,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan search result: %w", op, err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to search tasks: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// UpdateTaskById applies the non-empty fields of updates. A non-zero
+// updates.Version must match the current version of the task; the version is
+// incremented on every update.
+//
+// A new status is checked against the locked task: by check, unless it is
+// nil, and a task with unfinished blockers cannot move to IN_PROGRESS or
+// DONE, which fails with a *domain.BlockedError.
+func (r *Repository) UpdateTaskById(ctx context.Context, ownerId, id uuid.UUID, updates domain.Task, check domain.StatusCheck) error {
+	const op = "repo.postgresql.UpdateTaskById"
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		return updateTask(ctx, tx, ownerId, id, updates, check)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func updateTask(ctx context.Context, tx *sql.Tx, ownerId, id uuid.UUID, updates domain.Task, check domain.StatusCheck) error {
+	before, err := lockTask(tx, ownerId, id)
+	if err != nil {
+		return err
+	}
+	current := before.TaskStatus
+
+	if updates.Version != 0 && updates.Version != before.Version {
+		return domain.ErrVersionMismatch
+	}
+
+	if updates.TaskStatus != "" {
+		if err := checkStatus(tx, ownerId, before, updates.TaskStatus, check); err != nil {
+			return err
+		}
+	}
+
+	series := updatedSeries(before, updates, time.Now().UTC())
+
+	startAt := updatedTime(before.StartAt, updates.StartAt, updates.ClearStartAt)
+	dueAt := updatedTime(before.DueAt, updates.DueAt, updates.ClearDueAt)
+	if startAt != nil && dueAt != nil && startAt.After(*dueAt) {
+		return domain.ErrStartAfterDue
+	}
+
+	query := `
+        UPDATE tasks
+        SET 
+            title = COALESCE($1, title),
+            description = COALESCE($2, description),
+            status = COALESCE($3, status),
+            repeatable = COALESCE($4, repeatable),
+            start_at = $5,
+            due_at = $6,
+            priority = COALESCE($7, priority),
+            timezone = COALESCE($8, timezone),
+            series_id = $9,
+            series_start = $10,
+            occurrence = $11,
+            version = version + 1
+        WHERE id = $12
+    `
+
+	_, err = tx.Exec(query,
+		sql.NullString{String: updates.Title, Valid: updates.Title != ""},
+		sql.NullString{String: updates.Description, Valid: updates.Description != ""},
+		sql.NullString{String: string(updates.TaskStatus), Valid: updates.TaskStatus != ""},
+		sql.NullString{String: string(updates.RepeatTask), Valid: updates.RepeatTask != ""},
+		nullTime(startAt),
+		nullTime(dueAt),
+		sql.NullString{String: string(updates.Priority), Valid: updates.Priority != ""},
+		sql.NullString{String: updates.Timezone, Valid: updates.Timezone != ""},
+		series.SeriesId,
+		series.SeriesStart,
+		series.Occurrence,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	if updates.Tags != nil {
+		if err = setTaskTags(tx, ownerId, id, updates.Tags); err != nil {
+			return fmt.Errorf("failed to set tags: %w", err)
+		}
+	}
+
+	if updates.TaskStatus != "" && updates.TaskStatus != current {
+		err = insertStatusChange(tx, domain.StatusChange{
+			TaskId: id,
+			From:   current,
+			To:     updates.TaskStatus,
+			Actor:  ownerId.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record status change: %w", err)
+		}
+	}
+
+	after, err := getTask(tx, ownerId, id)
+	if err != nil {
+		return err
+	}
+
+	return recordChange(ctx, tx, domain.AuditUpdate, ownerId.String(), &before, after)
+}
+
+// updatedSeries is the series of task after updates. A new period or time
+// zone starts a new series from now, led by the task itself: the occurrences
+// so far were counted in the old calendar, and the numbers of the old series
+// stay taken by its tasks, including deleted ones.
+func updatedSeries(task, updates domain.Task, now time.Time) domain.Task {
+	if (updates.RepeatTask == "" || updates.RepeatTask == task.RepeatTask) &&
+		(updates.Timezone == "" || updates.Timezone == task.Timezone) {
+		return task
+	}
+
+	task.SeriesId = task.Id
+	task.SeriesStart = now
+	task.Occurrence = 0
+	return task
+}
+
+// updatedTime is a date of the task after an update that sets it to value,
+// or removes it with clear, and keeps it otherwise.
+func updatedTime(current, value *time.Time, clear bool) *time.Time {
+	switch {
+	case clear:
+		return nil
+	case value != nil:
+		return value
+	default:
+		return current
+	}
+}
+
+func checkStatus(tx *sql.Tx, ownerId uuid.UUID, task domain.Task, to domain.TaskStatus, check domain.StatusCheck) error {
+	if check != nil {
+		if err := check(task.TaskStatus, to); err != nil {
+			return err
+		}
+	}
+
+	if to == task.TaskStatus || (to != domain.IN_PROGRESS && to != domain.DONE) {
+		return nil
+	}
+
+	blockers, err := unfinishedBlockers(tx, ownerId, task.Id)
+	if err != nil {
+		return fmt.Errorf("failed to get blockers: %w", err)
+	}
+	if len(blockers) > 0 {
+		return &domain.BlockedError{Blockers: blockers}
+	}
+
+	return nil
+}
+
+// lockOwner takes a transaction level advisory lock on one kind of change
+// (scope) for all tasks of the owner.
+func lockOwner(tx *sql.Tx, scope string, ownerId uuid.UUID) error {
+	_, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, scope+":"+ownerId.String())
+	return err
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
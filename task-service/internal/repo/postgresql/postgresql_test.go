@@ -0,0 +1,63 @@
+package postgresql
+
+import (
+	"task-service/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestUpdatedSeriesRestartsFromLaterOccurrence(t *testing.T) {
+	now := time.Date(2025, 5, 12, 8, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		Id:          uuid.New(),
+		RepeatTask:  domain.DAILY,
+		SeriesId:    uuid.New(),
+		SeriesStart: now.AddDate(0, 0, -3),
+		Occurrence:  3,
+	}
+
+	tests := []struct {
+		name    string
+		updates domain.Task
+	}{
+		{"period", domain.Task{RepeatTask: domain.WEEKLY}},
+		{"timezone", domain.Task{Timezone: "Europe/Berlin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series := updatedSeries(task, tt.updates, now)
+
+			// The old series keeps occurrences 0 to 3, so the restarted one
+			// must not number its occurrences within it.
+			if series.SeriesId != task.Id {
+				t.Errorf("updatedSeries().SeriesId = %s, want the task %s", series.SeriesId, task.Id)
+			}
+			if series.Occurrence != 0 {
+				t.Errorf("updatedSeries().Occurrence = %d, want 0", series.Occurrence)
+			}
+			if !series.SeriesStart.Equal(now) {
+				t.Errorf("updatedSeries().SeriesStart = %v, want %v", series.SeriesStart, now)
+			}
+		})
+	}
+}
+
+func TestUpdatedSeriesKeepsSeriesWithoutRestart(t *testing.T) {
+	now := time.Date(2025, 5, 12, 8, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		Id:          uuid.New(),
+		RepeatTask:  domain.DAILY,
+		SeriesId:    uuid.New(),
+		SeriesStart: now.AddDate(0, 0, -3),
+		Occurrence:  3,
+		Timezone:    "Europe/Berlin",
+	}
+
+	series := updatedSeries(task, domain.Task{Title: "Renamed", RepeatTask: domain.DAILY, Timezone: "Europe/Berlin"}, now)
+	if series.SeriesId != task.SeriesId || series.Occurrence != task.Occurrence || !series.SeriesStart.Equal(task.SeriesStart) {
+		t.Errorf("updatedSeries() = %s #%d from %v, want %s #%d from %v",
+			series.SeriesId, series.Occurrence, series.SeriesStart, task.SeriesId, task.Occurrence, task.SeriesStart)
+	}
+}
@@ -0,0 +1,228 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const projectQuery = `
+	SELECT p.id, p.name, p.description, p.owner_id, p.created_at,
+		(SELECT count(*) FROM tasks t WHERE t.project_id = p.id AND t.deleted_at IS NULL)
+	FROM projects p
+`
+
+func scanProject(row rowScanner) (domain.Project, error) {
+	var project domain.Project
+	err := row.Scan(
+		&project.Id,
+		&project.Name,
+		&project.Description,
+		&project.OwnerId,
+		&project.CreatedAt,
+		&project.TaskCount,
+	)
+	return project, err
+}
+
+// lockProject checks that the project belongs to the owner and keeps it from
+// being deleted until the transaction ends.
+func lockProject(tx *sql.Tx, ownerId, id uuid.UUID) error {
+	var found int
+	err := tx.QueryRow(`SELECT 1 FROM projects WHERE id = $1 AND owner_id = $2 FOR KEY SHARE`, id, ownerId).Scan(&found)
+	if err == sql.ErrNoRows {
+		return domain.ErrProjectNotFound
+	}
+	return err
+}
+
+func (r *Repository) SaveProject(project domain.Project) error {
+	const op = "repo.postgresql.SaveProject"
+
+	query := `
+		INSERT INTO projects (id, owner_id, name, description, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := r.db.Exec(query, project.Id, project.OwnerId, project.Name, project.Description, project.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return fmt.Errorf("%s: %w", op, domain.ErrProjectExists)
+		}
+		return fmt.Errorf("%s: failed to save project: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetProjectById(ownerId, id uuid.UUID) (domain.Project, error) {
+	const op = "repo.postgresql.GetProjectById"
+
+	project, err := scanProject(r.db.QueryRow(projectQuery+` WHERE p.id = $1 AND p.owner_id = $2`, id, ownerId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Project{}, fmt.Errorf("%s: %w", op, domain.ErrProjectNotFound)
+		}
+		return domain.Project{}, fmt.Errorf("%s: failed to get project by id: %w", op, err)
+	}
+
+	return project, nil
+}
+
+// ListProjects returns all projects of the owner ordered by name.
+func (r *Repository) ListProjects(ownerId uuid.UUID) ([]domain.Project, error) {
+	const op = "repo.postgresql.ListProjects"
+
+	rows, err := r.db.Query(projectQuery+` WHERE p.owner_id = $1 ORDER BY p.name, p.id`, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list projects: %w", op, err)
+	}
+	defer rows.Close()
+
+	var projects []domain.Project
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan project: %w", op, err)
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to list projects: %w", op, err)
+	}
+
+	return projects, nil
+}
+
+func (r *Repository) UpdateProjectById(ownerId, id uuid.UUID, updates domain.Project) error {
+	const op = "repo.postgresql.UpdateProjectById"
+
+	query := `
+		UPDATE projects
+		SET
+			name = COALESCE($1, name),
+			description = COALESCE($2, description)
+		WHERE id = $3 AND owner_id = $4
+	`
+
+	result, err := r.db.Exec(query,
+		sql.NullString{String: updates.Name, Valid: updates.Name != ""},
+		sql.NullString{String: updates.Description, Valid: updates.Description != ""},
+		id,
+		ownerId,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return fmt.Errorf("%s: %w", op, domain.ErrProjectExists)
+		}
+		return fmt.Errorf("%s: failed to update project: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to update project: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrProjectNotFound)
+	}
+
+	return nil
+}
+
+// DeleteProjectById removes the project and returns the ids of its tasks.
+// The tasks are kept and taken out of the project like by MoveTask: their
+// version is incremented and the change is audited, trashed tasks included.
+func (r *Repository) DeleteProjectById(ctx context.Context, ownerId, id uuid.UUID) ([]uuid.UUID, error) {
+	const op = "repo.postgresql.DeleteProjectById"
+
+	var tasks []uuid.UUID
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		// Waits for tasks being moved into the project and keeps new ones out.
+		var found int
+		err := tx.QueryRow(`SELECT 1 FROM projects WHERE id = $1 AND owner_id = $2 FOR UPDATE`, id, ownerId).Scan(&found)
+		if err == sql.ErrNoRows {
+			return domain.ErrProjectNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock project: %w", err)
+		}
+
+		tasks, err = taskIds(tx, `SELECT id FROM tasks WHERE project_id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("failed to get tasks of project: %w", err)
+		}
+
+		before, err := lockTasks(tx, tasks)
+		if err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE tasks
+			SET project_id = NULL, version = version + 1
+			WHERE id = ANY($1)
+		`
+		if _, err = tx.Exec(query, pq.Array(tasks)); err != nil {
+			return fmt.Errorf("failed to take tasks out of project: %w", err)
+		}
+
+		if err = recordChanges(ctx, tx, domain.AuditUpdate, ownerId.String(), before); err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(`DELETE FROM projects WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete project: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// MoveTask puts the task into the project, or takes it out of any project
+// when projectId is nil.
+func (r *Repository) MoveTask(ctx context.Context, ownerId, id uuid.UUID, projectId *uuid.UUID) error {
+	const op = "repo.postgresql.MoveTask"
+
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		if projectId != nil {
+			if err := lockProject(tx, ownerId, *projectId); err != nil {
+				return err
+			}
+		}
+
+		before, err := lockTask(tx, ownerId, id)
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(`UPDATE tasks SET project_id = $1, version = version + 1 WHERE id = $2`, projectId, id); err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+
+		after, err := getTask(tx, ownerId, id)
+		if err != nil {
+			return err
+		}
+
+		return recordChange(ctx, tx, domain.AuditUpdate, ownerId.String(), &before, after)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
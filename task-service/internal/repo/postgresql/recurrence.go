@@ -0,0 +1,103 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+)
+
+// GetPendingRecurrences returns repeating tasks whose next occurrence has not
+// been created yet, ordered by id and starting after the given one.
+func (r *Repository) GetPendingRecurrences(after uuid.UUID, limit int) ([]domain.Task, error) {
+	const op = "repo.postgresql.GetPendingRecurrences"
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE repeatable <> 'NEVER' AND NOT next_spawned AND deleted_at IS NULL AND id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get pending recurrences: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tasks []domain.Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to get pending recurrences: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// recurrenceActor is the actor of audit events of occurrences created by the
+// recurrence worker.
+const recurrenceActor = "recurrence"
+
+// SpawnNextOccurrence marks prev as spawned and inserts next in one
+// transaction. The conditional update makes it safe to call from several
+// replicas at once: only the first caller gets spawned == true.
+func (r *Repository) SpawnNextOccurrence(prev uuid.UUID, next domain.Task) (spawned bool, err error) {
+	const op = "repo.postgresql.SpawnNextOccurrence"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("%s: failed to begin transaction: %w", op, err)
+	}
+
+	query := `
+		UPDATE tasks
+		SET next_spawned = TRUE
+		WHERE id = $1 AND NOT next_spawned AND repeatable <> 'NEVER' AND deleted_at IS NULL
+	`
+
+	result, err := tx.Exec(query, prev)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("%s: failed to mark task as spawned: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("%s: failed to mark task as spawned: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err = insertTask(tx, next); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("%s: failed to insert next occurrence: %w", op, err)
+	}
+
+	task, err := getTask(tx, next.OwnerId, next.Id)
+	if err == nil {
+		err = recordChange(context.Background(), tx, domain.AuditCreate, recurrenceActor, nil, task)
+	}
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("%s: failed to commit transaction: %w", op, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,75 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// setTaskTags replaces the tags of a task, creating the owner's tags that do
+// not exist yet.
+func setTaskTags(tx *sql.Tx, ownerId, id uuid.UUID, tags []string) error {
+	if _, err := tx.Exec(`DELETE FROM task_tags WHERE task_id = $1`, id); err != nil {
+		return err
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO tags (owner_id, name)
+		SELECT $1, unnest($2::text[])
+		ON CONFLICT (owner_id, name) DO NOTHING
+	`
+	if _, err := tx.Exec(query, ownerId, pq.Array(tags)); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO task_tags (task_id, tag_id)
+		SELECT $1, id FROM tags WHERE owner_id = $2 AND name = ANY($3)
+	`
+	_, err := tx.Exec(query, id, ownerId, pq.Array(tags))
+	return err
+}
+
+// ListTags returns the owner's tags with the number of tasks using each, the
+// most used first.
+func (r *Repository) ListTags(ownerId uuid.UUID) ([]domain.TagUsage, error) {
+	const op = "repo.postgresql.ListTags"
+
+	query := `
+		SELECT g.name, count(t.id)
+		FROM tags g
+		LEFT JOIN task_tags tt ON tt.tag_id = g.id
+		LEFT JOIN tasks t ON t.id = tt.task_id AND t.deleted_at IS NULL
+		WHERE g.owner_id = $1
+		GROUP BY g.id, g.name
+		ORDER BY count(t.id) DESC, g.name
+	`
+
+	rows, err := r.db.Query(query, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list tags: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []domain.TagUsage
+	for rows.Next() {
+		var tag domain.TagUsage
+		if err := rows.Scan(&tag.Name, &tag.TaskCount); err != nil {
+			return nil, fmt.Errorf("%s: failed to scan tag: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to list tags: %w", op, err)
+	}
+
+	return tags, nil
+}
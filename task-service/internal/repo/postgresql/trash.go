@@ -0,0 +1,139 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-service/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ListTrash returns the deleted tasks of the owner, the most recently
+// deleted first.
+func (r *Repository) ListTrash(ownerId uuid.UUID, limit int) ([]domain.Task, error) {
+	const op = "repo.postgresql.ListTrash"
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks
+		WHERE owner_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC, id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(query, ownerId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list trash: %w", op, err)
+	}
+	defer rows.Close()
+
+	tasks := make([]domain.Task, 0, limit)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan task: %w", op, err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to list trash: %w", op, err)
+	}
+
+	return tasks, nil
+}
+
+// RestoreTask takes the task out of the trash together with the subtasks
+// that were deleted with it, and returns the ids of the restored tasks. If
+// the parent of the task is still in the trash, the task becomes a top level
+// task.
+func (r *Repository) RestoreTask(ctx context.Context, ownerId, id uuid.UUID) ([]uuid.UUID, error) {
+	const op = "repo.postgresql.RestoreTask"
+
+	var restored []uuid.UUID
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var deletedAt time.Time
+		err := tx.QueryRow(`SELECT deleted_at FROM tasks WHERE id = $1 AND owner_id = $2 AND deleted_at IS NOT NULL FOR UPDATE`, id, ownerId).Scan(&deletedAt)
+		if err == sql.ErrNoRows {
+			return domain.ErrTaskNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock task: %w", err)
+		}
+
+		query := `
+			WITH RECURSIVE subtree(id) AS (
+				SELECT id FROM tasks WHERE id = $1
+				UNION ALL
+				SELECT t.id FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.deleted_at = $2
+			)
+			SELECT id FROM subtree
+		`
+
+		restored, err = taskIds(tx, query, id, deletedAt)
+		if err != nil {
+			return fmt.Errorf("failed to get subtree: %w", err)
+		}
+
+		before, err := lockTasks(tx, restored)
+		if err != nil {
+			return err
+		}
+
+		query = `
+			UPDATE tasks
+			SET parent_id = NULL
+			WHERE id = $1 AND parent_id IN (SELECT id FROM tasks WHERE deleted_at IS NOT NULL)
+		`
+		if _, err = tx.Exec(query, id); err != nil {
+			return fmt.Errorf("failed to detach task: %w", err)
+		}
+
+		query = `
+			UPDATE tasks
+			SET deleted_at = NULL, version = version + 1
+			WHERE id = ANY($1)
+		`
+		if _, err = tx.Exec(query, pq.Array(restored)); err != nil {
+			return fmt.Errorf("failed to restore task: %w", err)
+		}
+
+		return recordChanges(ctx, tx, domain.AuditRestore, ownerId.String(), before)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return restored, nil
+}
+
+// PurgeDeletedTasks permanently removes up to limit tasks deleted before the
+// given time and returns how many were removed.
+func (r *Repository) PurgeDeletedTasks(before time.Time, limit int) (int, error) {
+	const op = "repo.postgresql.PurgeDeletedTasks"
+
+	query := `
+		DELETE FROM tasks
+		WHERE id IN (
+			SELECT id FROM tasks
+			WHERE deleted_at < $1
+			ORDER BY deleted_at
+			LIMIT $2
+		)
+	`
+
+	result, err := r.db.Exec(query, before.UTC(), limit)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to purge tasks: %w", op, err)
+	}
+
+	purged, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to purge tasks: %w", op, err)
+	}
+
+	return int(purged), nil
+}
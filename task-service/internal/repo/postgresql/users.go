@@ -0,0 +1,59 @@
+package postgresql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"task-service/domain"
+
+	"github.com/lib/pq"
+)
+
+const uniqueViolation = "23505"
+
+func (r *Repository) SaveUser(user domain.User) error {
+	const op = "repo.postgresql.SaveUser"
+
+	query := `
+		INSERT INTO users (id, email, password_hash, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.Exec(query, user.Id, user.Email, user.PasswordHash, user.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return fmt.Errorf("%s: %w", op, domain.ErrUserExists)
+		}
+		return fmt.Errorf("%s: failed to save user: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetUserByEmail(email string) (domain.User, error) {
+	const op = "repo.postgresql.GetUserByEmail"
+
+	query := `
+		SELECT id, email, password_hash, created_at
+		FROM users
+		WHERE email = $1
+	`
+
+	var user domain.User
+	err := r.db.QueryRow(query, email).Scan(
+		&user.Id,
+		&user.Email,
+		&user.PasswordHash,
+		&user.CreatedAt,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.User{}, fmt.Errorf("%s: %w", op, domain.ErrUserNotFound)
+		}
+		return domain.User{}, fmt.Errorf("%s: failed to get user by email: %w", op, err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,383 @@
+package postgresql
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"task-service/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const webhookQuery = `SELECT id, owner_id, url, events, secret, active, created_at FROM webhooks`
+
+func scanWebhook(row rowScanner) (domain.Webhook, error) {
+	var webhook domain.Webhook
+	var events []string
+	err := row.Scan(
+		&webhook.Id,
+		&webhook.OwnerId,
+		&webhook.URL,
+		pq.Array(&events),
+		&webhook.Secret,
+		&webhook.Active,
+		&webhook.CreatedAt,
+	)
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, domain.EventType(event))
+	}
+	return webhook, err
+}
+
+func eventNames(events []domain.EventType) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+	return names
+}
+
+// enqueueDeliveries schedules the delivery of an outbox event to every active
+// webhook of the owner subscribed to it.
+func enqueueDeliveries(tx *sql.Tx, ownerId uuid.UUID, eventId int64, eventType domain.EventType, payload []byte) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, payload)
+		SELECT id, $2::bigint, $3::text, $4::jsonb
+		FROM webhooks
+		WHERE owner_id = $1 AND active AND (cardinality(events) = 0 OR $3::text = ANY(events))
+	`
+
+	if _, err := tx.Exec(query, ownerId, eventId, eventType, payload); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) SaveWebhook(webhook domain.Webhook) error {
+	const op = "repo.postgresql.SaveWebhook"
+
+	query := `
+		INSERT INTO webhooks (id, owner_id, url, events, secret, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(query,
+		webhook.Id,
+		webhook.OwnerId,
+		webhook.URL,
+		pq.Array(eventNames(webhook.Events)),
+		webhook.Secret,
+		webhook.Active,
+		webhook.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("%s: failed to save webhook: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetWebhookById(ownerId, id uuid.UUID) (domain.Webhook, error) {
+	const op = "repo.postgresql.GetWebhookById"
+
+	webhook, err := scanWebhook(r.db.QueryRow(webhookQuery+` WHERE id = $1 AND owner_id = $2`, id, ownerId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Webhook{}, fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+		}
+		return domain.Webhook{}, fmt.Errorf("%s: failed to get webhook by id: %w", op, err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks returns all webhooks of the owner, oldest first.
+func (r *Repository) ListWebhooks(ownerId uuid.UUID) ([]domain.Webhook, error) {
+	const op = "repo.postgresql.ListWebhooks"
+
+	rows, err := r.db.Query(webhookQuery+` WHERE owner_id = $1 ORDER BY created_at, id`, ownerId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to list webhooks: %w", op, err)
+	}
+	defer rows.Close()
+
+	var webhooks []domain.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan webhook: %w", op, err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to list webhooks: %w", op, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *Repository) UpdateWebhookById(ownerId, id uuid.UUID, updates domain.WebhookUpdate) error {
+	const op = "repo.postgresql.UpdateWebhookById"
+
+	var events any
+	if updates.Events != nil {
+		events = pq.Array(eventNames(*updates.Events))
+	}
+
+	query := `
+		UPDATE webhooks
+		SET
+			url = COALESCE($1, url),
+			events = COALESCE($2, events),
+			secret = COALESCE($3, secret),
+			active = COALESCE($4, active)
+		WHERE id = $5 AND owner_id = $6
+	`
+
+	result, err := r.db.Exec(query, updates.URL, events, updates.Secret, updates.Active, id, ownerId)
+	if err != nil {
+		return fmt.Errorf("%s: failed to update webhook: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to update webhook: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// DeleteWebhookById removes the webhook together with its delivery log.
+func (r *Repository) DeleteWebhookById(ownerId, id uuid.UUID) error {
+	const op = "repo.postgresql.DeleteWebhookById"
+
+	result, err := r.db.Exec(`DELETE FROM webhooks WHERE id = $1 AND owner_id = $2`, id, ownerId)
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete webhook: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a webhook, newest first,
+// optionally only deliveries in one status.
+func (r *Repository) ListDeliveries(filter domain.DeliveryFilter) (domain.DeliveryPage, error) {
+	const op = "repo.postgresql.ListDeliveries"
+
+	if _, err := r.GetWebhookById(filter.OwnerId, filter.WebhookId); err != nil {
+		return domain.DeliveryPage{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+
+	before := int64(0)
+	if filter.Cursor != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(filter.Cursor)
+		if err != nil {
+			return domain.DeliveryPage{}, fmt.Errorf("%s: %w", op, domain.ErrInvalidCursor)
+		}
+		before, err = strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return domain.DeliveryPage{}, fmt.Errorf("%s: %w", op, domain.ErrInvalidCursor)
+		}
+	}
+
+	query := `
+		SELECT id, webhook_id, event_id, event_type, payload, status, attempts,
+			next_attempt_at, last_status_code, last_error, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+			AND ($2::text = '' OR status = $2::text)
+			AND ($3::bigint = 0 OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(query, filter.WebhookId, filter.Status, before, limit+1)
+	if err != nil {
+		return domain.DeliveryPage{}, fmt.Errorf("%s: failed to list deliveries: %w", op, err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0, limit)
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		var nextAttemptAt time.Time
+		err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&delivery.EventId,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&nextAttemptAt,
+			&delivery.LastStatusCode,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return domain.DeliveryPage{}, fmt.Errorf("%s: failed to scan delivery: %w", op, err)
+		}
+		if delivery.Status == domain.DeliveryPending {
+			delivery.NextAttemptAt = &nextAttemptAt
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return domain.DeliveryPage{}, fmt.Errorf("%s: failed to list deliveries: %w", op, err)
+	}
+
+	page := domain.DeliveryPage{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		page.Deliveries = deliveries[:limit]
+		last := page.Deliveries[limit-1].Id
+		page.NextCursor = base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(last, 10)))
+	}
+
+	return page, nil
+}
+
+// RetryDelivery schedules a delivery of the owner's webhook for immediate
+// delivery with a fresh attempt budget, dead deliveries included.
+func (r *Repository) RetryDelivery(ownerId, webhookId uuid.UUID, id int64) error {
+	const op = "repo.postgresql.RetryDelivery"
+
+	query := `
+		UPDATE webhook_deliveries d
+		SET status = $1, attempts = 0, next_attempt_at = $2
+		FROM webhooks w
+		WHERE d.id = $3 AND d.webhook_id = $4 AND w.id = d.webhook_id AND w.owner_id = $5
+	`
+
+	result, err := r.db.Exec(query, domain.DeliveryPending, time.Now().UTC(), id, webhookId, ownerId)
+	if err != nil {
+		return fmt.Errorf("%s: failed to retry delivery: %w", op, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: failed to retry delivery: %w", op, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%s: %w", op, domain.ErrDeliveryNotFound)
+	}
+
+	return nil
+}
+
+// ClaimDeliveries returns up to limit pending deliveries of active webhooks
+// that are due and counts the attempt. Claimed deliveries are not due again
+// until lease passes, so concurrent workers do not send them twice and a
+// delivery whose worker stopped is picked up again.
+func (r *Repository) ClaimDeliveries(limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	const op = "repo.postgresql.ClaimDeliveries"
+
+	now := time.Now().UTC()
+
+	query := `
+		UPDATE webhook_deliveries d
+		SET attempts = d.attempts + 1, next_attempt_at = $1
+		FROM webhooks w
+		WHERE w.id = d.webhook_id AND d.id IN (
+			SELECT dd.id
+			FROM webhook_deliveries dd
+			JOIN webhooks ww ON ww.id = dd.webhook_id
+			WHERE dd.status = $2 AND dd.next_attempt_at <= $3 AND ww.active
+			ORDER BY dd.next_attempt_at, dd.id
+			LIMIT $4
+			FOR UPDATE OF dd SKIP LOCKED
+		)
+		RETURNING d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, d.created_at, w.url, w.secret
+	`
+
+	rows, err := r.db.Query(query, now.Add(lease), domain.DeliveryPending, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to claim deliveries: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		delivery := domain.WebhookDelivery{Status: domain.DeliveryPending}
+		err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&delivery.EventId,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.CreatedAt,
+			&delivery.URL,
+			&delivery.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to scan delivery: %w", op, err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: failed to claim deliveries: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordDeliveryAttempt stores the outcome of the last attempt of a delivery.
+func (r *Repository) RecordDeliveryAttempt(id int64, attempt domain.DeliveryAttempt) error {
+	const op = "repo.postgresql.RecordDeliveryAttempt"
+
+	query := `
+		UPDATE webhook_deliveries
+		SET
+			status = $1,
+			last_status_code = $2,
+			last_error = $3,
+			next_attempt_at = $4,
+			delivered_at = COALESCE($5, delivered_at)
+		WHERE id = $6
+	`
+
+	now := time.Now().UTC()
+	nextAttemptAt := attempt.NextAttemptAt.UTC()
+	if attempt.Status != domain.DeliveryPending {
+		nextAttemptAt = now
+	}
+
+	var deliveredAt *time.Time
+	if attempt.Status == domain.DeliveryDelivered {
+		deliveredAt = &now
+	}
+
+	_, err := r.db.Exec(query, attempt.Status, attempt.StatusCode, attempt.Error, nextAttemptAt, deliveredAt, id)
+	if err != nil {
+		return fmt.Errorf("%s: failed to record delivery attempt: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"task-service/internal/lib/breaker"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// breakerHook puts every command and pipeline behind the circuit breaker.
+// Replies of the server, redis.Nil included, are successes: only commands
+// that did not reach Redis count as failures.
+type breakerHook struct {
+	breaker *breaker.Breaker
+}
+
+func (h breakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h breakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+
+		err := next(ctx, cmd)
+		h.breaker.Record(failure(err))
+		return err
+	}
+}
+
+func (h breakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+
+		err := next(ctx, cmds)
+		h.breaker.Record(failure(err))
+		return err
+	}
+}
+
+func failure(err error) error {
+	var reply redis.Error
+	if err == nil || errors.As(err, &reply) || errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
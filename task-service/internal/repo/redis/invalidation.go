@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// PublishInvalidation tells every replica subscribed to the channel, this
+// one included, to drop its local copies of the tasks.
+func (r *RedisDB) PublishInvalidation(ctx context.Context, channel string, ids ...uuid.UUID) error {
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("failed to marshal invalidation: %w", err)
+	}
+
+	if err := r.rdb.Publish(ctx, channel, raw).Err(); err != nil {
+		return fmt.Errorf("failed to publish invalidation to channel %s: %w", channel, err)
+	}
+
+	return nil
+}
+
+// SubscribeInvalidations returns the task ids published to the channel until
+// ctx is done. Like SubscribeEvents, the subscription is restored after
+// connection errors and messages sent in between are lost.
+func (r *RedisDB) SubscribeInvalidations(ctx context.Context, channel string) <-chan []uuid.UUID {
+	pubsub := r.rdb.Subscribe(ctx, channel)
+	invalidations := make(chan []uuid.UUID, 256)
+
+	go func() {
+		defer close(invalidations)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var ids []uuid.UUID
+				if err := json.Unmarshal([]byte(msg.Payload), &ids); err != nil {
+					continue
+				}
+
+				select {
+				case invalidations <- ids:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return invalidations
+}
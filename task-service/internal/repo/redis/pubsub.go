@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"task-service/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// eventMessage is a domain event as it is sent over Pub/Sub.
+type eventMessage struct {
+	Id        int64           `json:"id"`
+	Type      string          `json:"type"`
+	TaskId    uuid.UUID       `json:"task_id"`
+	OwnerId   uuid.UUID       `json:"owner_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// ChannelPublisher broadcasts domain events to every replica subscribed to a
+// Pub/Sub channel. Unlike the stream, messages sent while a replica is not
+// subscribed are lost.
+type ChannelPublisher struct {
+	rdb     *redis.Client
+	channel string
+}
+
+func (r *RedisDB) Channel(channel string) *ChannelPublisher {
+	return &ChannelPublisher{rdb: r.rdb, channel: channel}
+}
+
+func (p *ChannelPublisher) Publish(ctx context.Context, event domain.Event) error {
+	raw, err := json.Marshal(eventMessage{
+		Id:        event.Id,
+		Type:      string(event.Type),
+		TaskId:    event.TaskId,
+		OwnerId:   event.OwnerId,
+		CreatedAt: event.CreatedAt,
+		Payload:   event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %d: %w", event.Id, err)
+	}
+
+	if err = p.rdb.Publish(ctx, p.channel, raw).Err(); err != nil {
+		return fmt.Errorf("failed to publish event %d to channel %s: %w", event.Id, p.channel, err)
+	}
+
+	return nil
+}
+
+// SubscribeEvents returns the events published to the channel until ctx is
+// done. The subscription is restored after connection errors; messages that
+// cannot be decoded are skipped.
+func (r *RedisDB) SubscribeEvents(ctx context.Context, channel string) <-chan domain.Event {
+	pubsub := r.rdb.Subscribe(ctx, channel)
+	events := make(chan domain.Event, 256)
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var message eventMessage
+				if err := json.Unmarshal([]byte(msg.Payload), &message); err != nil {
+					continue
+				}
+
+				select {
+				case events <- domain.Event{
+					Id:        message.Id,
+					Type:      domain.EventType(message.Type),
+					TaskId:    message.TaskId,
+					OwnerId:   message.OwnerId,
+					Payload:   message.Payload,
+					CreatedAt: message.CreatedAt,
+				}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events
+}
@@ -1,49 +1,162 @@
-package redis
-
-import (
-	"context"
-	"fmt"
-	"task-service/internal/config"
-	"time"
-
-	"github.com/redis/go-redis/v9"
-)
-
-type RedisDB struct {
-	rdb *redis.Client
-}
-
-func NewClient(ctx context.Context, cfg *config.Config) (*RedisDB, error) {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:        cfg.Redis.Host + ":" + fmt.Sprint(cfg.Redis.Port),
-		Password:    cfg.Redis.Password,
-		DB:          cfg.Redis.DB,
-		Username:    cfg.Redis.User,
-		MaxRetries:  cfg.Redis.MaxRetries,
-		DialTimeout: cfg.Redis.DialTimeout,
-	})
-
-	if err := rdb.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("Failed to connect to redis: %s", err.Error())
-	}
-	return &RedisDB{rdb: rdb}, nil
-}
-
-func (r *RedisDB) Get(ctx context.Context, uuid string) (string, error) {
-	cached, err := r.rdb.Get(ctx, uuid).Result()
-	if err == redis.Nil {
-		return "", nil
-	}
-	if err != nil {
-		return "", err
-	}
-	return cached, nil
-}
-
-func (r *RedisDB) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
-	return r.rdb.Set(ctx, key, value, ttl).Err()
-}
-
-func (r *RedisDB) Delete(ctx context.Context, key string) error {
-	return r.rdb.Del(ctx, key).Err()
-}
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"task-service/internal/config"
+	"task-service/internal/lib/breaker"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisDB struct {
+	rdb     *redis.Client
+	log     *slog.Logger
+	breaker *breaker.Breaker
+}
+
+// NewClient does not connect yet: Redis is only a cache, so the service
+// starts without it and the client connects once Redis is reachable. Calls
+// fail fast with breaker.ErrOpen while Redis keeps failing.
+func NewClient(log *slog.Logger, cfg *config.Config) *RedisDB {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:        cfg.Redis.Host + ":" + fmt.Sprint(cfg.Redis.Port),
+		Password:    cfg.Redis.Password,
+		DB:          cfg.Redis.DB,
+		Username:    cfg.Redis.User,
+		MaxRetries:  cfg.Redis.MaxRetries,
+		DialTimeout: cfg.Redis.DialTimeout,
+	})
+
+	b := breaker.New(log, "redis", cfg.Redis.Breaker.Threshold, cfg.Redis.Breaker.Timeout)
+	rdb.AddHook(breakerHook{breaker: b})
+
+	return &RedisDB{
+		rdb:     rdb,
+		log:     log.With(slog.String("component", "repo/redis")),
+		breaker: b,
+	}
+}
+
+func (r *RedisDB) Ping(ctx context.Context) error {
+	if err := r.rdb.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("Failed to connect to redis: %s", err.Error())
+	}
+	return nil
+}
+
+// Close closes the connections and the subscriptions still open. Calls
+// made after it fail.
+func (r *RedisDB) Close() error {
+	return r.rdb.Close()
+}
+
+// State is the state of the circuit breaker in front of Redis.
+func (r *RedisDB) State() breaker.State {
+	return r.breaker.State()
+}
+
+// Watch pings Redis every interval until ctx is done, so the client
+// reconnects and the breaker closes even when no request uses the cache.
+func (r *RedisDB) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	available := r.Ping(ctx) == nil
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := r.Ping(ctx)
+			switch {
+			case err == nil && !available:
+				r.log.Info("Redis is available again")
+			case err != nil && available:
+				r.log.Warn("Redis is unavailable, serving without cache", sl.Error(err))
+			}
+			available = err == nil
+		}
+	}
+}
+
+func (r *RedisDB) Get(ctx context.Context, uuid string) (string, error) {
+	cached, err := r.rdb.Get(ctx, uuid).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return cached, nil
+}
+
+// setIfNotOlder stores ARGV[1] unless the key holds a JSON object whose
+// version is higher than ARGV[2]. Values without a version are overwritten.
+var setIfNotOlder = redis.NewScript(`
+local current = redis.call('GET', KEYS[1])
+if current then
+	local ok, decoded = pcall(cjson.decode, current)
+	if ok and type(decoded) == 'table' and type(decoded.version) == 'number' and decoded.version > tonumber(ARGV[2]) then
+		return 0
+	end
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+return 1
+`)
+
+// SetIfNotOlder stores a JSON value with a version field unless the key
+// already holds a higher version, and reports whether it was stored. The
+// check and the write are atomic.
+func (r *RedisDB) SetIfNotOlder(ctx context.Context, key string, value string, version int64, ttl time.Duration) (bool, error) {
+	stored, err := setIfNotOlder.Run(ctx, r.rdb, []string{key}, value, version, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return stored == 1, nil
+}
+
+// Versioned is a value for SetManyIfNotOlder.
+type Versioned struct {
+	Key     string
+	Value   string
+	Version int64
+	TTL     time.Duration
+}
+
+// SetManyIfNotOlder is SetIfNotOlder for several keys, sent in one pipeline.
+// It reports for each value whether it was stored. The script is sent with
+// every key: a pipeline cannot fall back from EVALSHA when Redis does not
+// have it cached.
+func (r *RedisDB) SetManyIfNotOlder(ctx context.Context, values []Versioned) ([]bool, error) {
+	pipe := r.rdb.Pipeline()
+	cmds := make([]*redis.Cmd, len(values))
+	for i, v := range values {
+		cmds[i] = setIfNotOlder.Eval(ctx, pipe, []string{v.Key}, v.Value, v.Version, v.TTL.Milliseconds())
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	stored := make([]bool, len(cmds))
+	for i, cmd := range cmds {
+		n, err := cmd.Int()
+		if err != nil {
+			return nil, err
+		}
+		stored[i] = n == 1
+	}
+	return stored, nil
+}
+
+func (r *RedisDB) Delete(ctx context.Context, keys ...string) error {
+	return r.rdb.Del(ctx, keys...).Err()
+}
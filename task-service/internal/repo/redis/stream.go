@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"task-service/domain"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamPublisher publishes domain events to a Redis stream. Consumers read
+// it with consumer groups; the outbox id in every entry lets them drop the
+// duplicates at-least-once delivery can produce.
+type StreamPublisher struct {
+	rdb    *redis.Client
+	stream string
+	maxLen int64
+}
+
+// Streams returns a publisher appending to the given stream, trimmed to about
+// maxLen entries. maxLen 0 disables trimming.
+func (r *RedisDB) Streams(stream string, maxLen int64) *StreamPublisher {
+	return &StreamPublisher{rdb: r.rdb, stream: stream, maxLen: maxLen}
+}
+
+func (p *StreamPublisher) Publish(ctx context.Context, event domain.Event) error {
+	err := p.rdb.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: true,
+		Values: map[string]any{
+			"event_id":   event.Id,
+			"event_type": string(event.Type),
+			"task_id":    event.TaskId.String(),
+			"created_at": event.CreatedAt.UTC().Format(time.RFC3339Nano),
+			"payload":    string(event.Payload),
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add event %d to stream %s: %w", event.Id, p.stream, err)
+	}
+
+	return nil
+}
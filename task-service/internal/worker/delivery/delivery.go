@@ -0,0 +1,195 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"task-service/domain"
+	"task-service/internal/config"
+	"task-service/internal/lib/logger/sl"
+	"task-service/internal/lib/webhook"
+	"time"
+)
+
+// maxDrainBody is how much of a response is read, so the connection can be
+// reused.
+const maxDrainBody = 4096
+
+type DeliveryStore interface {
+	ClaimDeliveries(limit int, lease time.Duration) ([]domain.WebhookDelivery, error)
+	RecordDeliveryAttempt(id int64, attempt domain.DeliveryAttempt) error
+}
+
+// Worker sends pending webhook deliveries. A delivery succeeds on a 2xx
+// response. Failed deliveries are retried with exponential backoff and are
+// marked dead after the last attempt.
+type Worker struct {
+	log         *slog.Logger
+	store       DeliveryStore
+	client      *http.Client
+	interval    time.Duration
+	batchSize   int
+	concurrency int
+	maxAttempts int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+	timeout     time.Duration
+}
+
+func New(log *slog.Logger, store DeliveryStore, client *http.Client, cfg config.Webhook) *Worker {
+	return &Worker{
+		log:         log.With(slog.String("component", "worker/delivery")),
+		store:       store,
+		client:      client,
+		interval:    cfg.Interval,
+		batchSize:   cfg.BatchSize,
+		concurrency: max(cfg.Concurrency, 1),
+		maxAttempts: cfg.MaxAttempts,
+		backoffBase: cfg.BackoffBase,
+		backoffMax:  cfg.BackoffMax,
+		timeout:     cfg.Timeout,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("delivery worker started", slog.String("interval", w.interval.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil {
+			n, err := w.deliverBatch(ctx)
+			if err != nil {
+				w.log.Error("Failed to deliver webhooks", sl.Error(err))
+			}
+			if err != nil || n < w.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("delivery worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverBatch claims a batch of due deliveries and sends them with at most
+// concurrency requests in flight.
+func (w *Worker) deliverBatch(ctx context.Context) (int, error) {
+	// The lease outlasts every attempt, so a delivery is not claimed again
+	// while it is being sent.
+	deliveries, err := w.store.ClaimDeliveries(w.batchSize, 2*w.timeout+time.Minute)
+	if err != nil {
+		return 0, err
+	}
+
+	sem := make(chan struct{}, w.concurrency)
+	var wg sync.WaitGroup
+
+	for _, delivery := range deliveries {
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			attempt := w.attempt(ctx, delivery)
+			if err := w.store.RecordDeliveryAttempt(delivery.Id, attempt); err != nil {
+				w.log.Error("Failed to record delivery attempt", slog.Int64("delivery", delivery.Id), sl.Error(err))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (w *Worker) attempt(ctx context.Context, delivery domain.WebhookDelivery) domain.DeliveryAttempt {
+	statusCode, err := w.send(ctx, delivery)
+	if err == nil {
+		w.log.Info("Webhook delivered", slog.Int64("delivery", delivery.Id), slog.Int("status", statusCode))
+		return domain.DeliveryAttempt{Status: domain.DeliveryDelivered, StatusCode: statusCode}
+	}
+
+	attempt := domain.DeliveryAttempt{
+		Status:     domain.DeliveryPending,
+		StatusCode: statusCode,
+		Error:      err.Error(),
+	}
+
+	if delivery.Attempts >= w.maxAttempts {
+		attempt.Status = domain.DeliveryDead
+		w.log.Warn("Webhook delivery is dead", slog.Int64("delivery", delivery.Id), slog.Int("attempts", delivery.Attempts), sl.Error(err))
+		return attempt
+	}
+
+	attempt.NextAttemptAt = time.Now().Add(w.backoff(delivery.Attempts))
+	w.log.Info("Webhook delivery failed", slog.Int64("delivery", delivery.Id), slog.Int("attempts", delivery.Attempts), sl.Error(err))
+
+	return attempt
+}
+
+// backoff returns the wait after the given attempt: backoffBase doubled for
+// every earlier attempt, at most backoffMax.
+func (w *Worker) backoff(attempts int) time.Duration {
+	wait := w.backoffBase
+	for i := 1; i < attempts && wait < w.backoffMax; i++ {
+		wait *= 2
+	}
+	return min(wait, w.backoffMax)
+}
+
+// send posts the signed delivery and returns the response status, 0 if no
+// response was received.
+func (w *Worker) send(ctx context.Context, delivery domain.WebhookDelivery) (int, error) {
+	body, err := webhook.NewBody(delivery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build body: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-service-webhook")
+	req.Header.Set(webhook.HeaderEvent, string(delivery.EventType))
+	req.Header.Set(webhook.HeaderDelivery, strconv.FormatInt(delivery.Id, 10))
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(delivery.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// The body is not kept: the delivery log would hand it to the owner of
+	// the webhook, whoever answered.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"task-service/domain"
+	"task-service/internal/config"
+	"task-service/internal/lib/webhook"
+	"testing"
+	"time"
+)
+
+// fakeStore hands out the deliveries once and keeps the recorded attempts.
+type fakeStore struct {
+	mu         sync.Mutex
+	deliveries []domain.WebhookDelivery
+	attempts   map[int64]domain.DeliveryAttempt
+}
+
+func (s *fakeStore) ClaimDeliveries(limit int, lease time.Duration) ([]domain.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	claimed := s.deliveries
+	s.deliveries = nil
+	return claimed, nil
+}
+
+func (s *fakeStore) RecordDeliveryAttempt(id int64, attempt domain.DeliveryAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attempts[id] = attempt
+	return nil
+}
+
+func newWorker(deliveries ...domain.WebhookDelivery) (*Worker, *fakeStore) {
+	store := &fakeStore{deliveries: deliveries, attempts: make(map[int64]domain.DeliveryAttempt)}
+	w := New(slog.New(slog.NewTextHandler(io.Discard, nil)), store, &http.Client{}, config.Webhook{
+		BatchSize:   10,
+		Concurrency: 2,
+		Timeout:     5 * time.Second,
+		MaxAttempts: 3,
+		BackoffBase: 30 * time.Second,
+		BackoffMax:  time.Minute,
+	})
+	return w, store
+}
+
+func newDelivery(id int64, url string, attempts int) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		Id:        id,
+		EventId:   42,
+		EventType: domain.TaskUpdated,
+		Payload:   []byte(`{"id":"3f0c6b1e-8a8e-4c55-9d7b-0f3b1c2d4e5f"}`),
+		Attempts:  attempts,
+		CreatedAt: time.Now(),
+		URL:       url,
+		Secret:    "s3cret",
+	}
+}
+
+func TestDeliverSignsRequest(t *testing.T) {
+	var header http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w, store := newWorker(newDelivery(1, srv.URL, 1))
+	if _, err := w.deliverBatch(context.Background()); err != nil {
+		t.Fatalf("deliverBatch() error = %v", err)
+	}
+
+	if got := store.attempts[1]; got.Status != domain.DeliveryDelivered || got.StatusCode != http.StatusNoContent {
+		t.Fatalf("attempt = %+v, want delivered with 204", got)
+	}
+
+	if got := header.Get(webhook.HeaderEvent); got != string(domain.TaskUpdated) {
+		t.Errorf("%s = %q, want %q", webhook.HeaderEvent, got, domain.TaskUpdated)
+	}
+	if got := header.Get(webhook.HeaderDelivery); got != "1" {
+		t.Errorf("%s = %q, want 1", webhook.HeaderDelivery, got)
+	}
+	timestamp, err := strconv.ParseInt(header.Get(webhook.HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s is not a Unix time: %v", webhook.HeaderTimestamp, err)
+	}
+	if !webhook.Verify("s3cret", timestamp, body, header.Get(webhook.HeaderSignature)) {
+		t.Errorf("%s = %q does not verify", webhook.HeaderSignature, header.Get(webhook.HeaderSignature))
+	}
+}
+
+func TestDeliverRetriesWithBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal details", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	w, store := newWorker(newDelivery(1, srv.URL, 1), newDelivery(2, srv.URL, 2))
+	before := time.Now()
+	if _, err := w.deliverBatch(context.Background()); err != nil {
+		t.Fatalf("deliverBatch() error = %v", err)
+	}
+
+	for id, wait := range map[int64]time.Duration{1: 30 * time.Second, 2: time.Minute} {
+		got := store.attempts[id]
+		if got.Status != domain.DeliveryPending || got.StatusCode != http.StatusBadGateway {
+			t.Errorf("attempt %d = %+v, want pending with 502", id, got)
+		}
+		if got.NextAttemptAt.Before(before.Add(wait)) || got.NextAttemptAt.After(time.Now().Add(wait)) {
+			t.Errorf("attempt %d is retried at %v, want %v after the attempt", id, got.NextAttemptAt, wait)
+		}
+		if strings.Contains(got.Error, "internal details") {
+			t.Errorf("attempt %d error = %q, keeps the response body", id, got.Error)
+		}
+	}
+}
+
+func TestDeliverMarksLastAttemptDead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	w, store := newWorker(newDelivery(1, srv.URL, 3))
+	if _, err := w.deliverBatch(context.Background()); err != nil {
+		t.Fatalf("deliverBatch() error = %v", err)
+	}
+
+	if got := store.attempts[1]; got.Status != domain.DeliveryDead || !got.NextAttemptAt.IsZero() {
+		t.Errorf("attempt = %+v, want dead without a next attempt", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	w, _ := newWorker()
+
+	for attempts, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 5: time.Minute} {
+		if got := w.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
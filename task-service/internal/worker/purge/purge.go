@@ -0,0 +1,76 @@
+package purge
+
+import (
+	"context"
+	"log/slog"
+	"task-service/internal/config"
+	"task-service/internal/lib/logger/sl"
+	"time"
+)
+
+type TaskPurger interface {
+	PurgeDeletedTasks(before time.Time, limit int) (int, error)
+}
+
+// Worker permanently removes tasks that have been in the trash for longer
+// than the retention period.
+type Worker struct {
+	log       *slog.Logger
+	repo      TaskPurger
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+func New(log *slog.Logger, repo TaskPurger, cfg config.Trash) *Worker {
+	return &Worker{
+		log:       log.With(slog.String("component", "worker/purge")),
+		repo:      repo,
+		interval:  cfg.PurgeInterval,
+		retention: cfg.Retention,
+		batchSize: cfg.BatchSize,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("purge worker started", slog.String("interval", w.interval.String()), slog.String("retention", w.retention.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := w.purge(ctx, time.Now().Add(-w.retention))
+		if err != nil {
+			w.log.Error("Failed to purge deleted tasks", sl.Error(err))
+		} else if purged > 0 {
+			w.log.Info("Deleted tasks purged", slog.Int("count", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("purge worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge removes the tasks in batches so one run does not hold a long
+// transaction over a large trash.
+func (w *Worker) purge(ctx context.Context, before time.Time) (int, error) {
+	purged := 0
+
+	for ctx.Err() == nil {
+		n, err := w.repo.PurgeDeletedTasks(before, w.batchSize)
+		if err != nil {
+			return purged, err
+		}
+		purged += n
+
+		if n < w.batchSize {
+			break
+		}
+	}
+
+	return purged, nil
+}
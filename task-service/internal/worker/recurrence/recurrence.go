@@ -0,0 +1,180 @@
+package recurrence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"task-service/domain"
+	"task-service/internal/config"
+	"task-service/internal/lib/calendar"
+	"task-service/internal/lib/logger/sl"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskRecurrer interface {
+	GetPendingRecurrences(after uuid.UUID, limit int) ([]domain.Task, error)
+	SpawnNextOccurrence(prev uuid.UUID, next domain.Task) (bool, error)
+}
+
+// Worker creates the next occurrence of a repeating task once the current one
+// is DONE or its period has elapsed.
+type Worker struct {
+	log       *slog.Logger
+	repo      TaskRecurrer
+	interval  time.Duration
+	batchSize int
+	loc       *time.Location
+	zones     map[string]*time.Location
+}
+
+func New(log *slog.Logger, repo TaskRecurrer, cfg config.Recurrence) (*Worker, error) {
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timezone %q: %w", cfg.Timezone, err)
+	}
+
+	return &Worker{
+		log:       log.With(slog.String("component", "worker/recurrence")),
+		repo:      repo,
+		interval:  cfg.Interval,
+		batchSize: cfg.BatchSize,
+		loc:       loc,
+		zones:     make(map[string]*time.Location),
+	}, nil
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("recurrence worker started", slog.String("interval", w.interval.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		spawned, err := w.materialize(ctx, time.Now())
+		if err != nil {
+			w.log.Error("Failed to materialize recurring tasks", sl.Error(err))
+		} else if spawned > 0 {
+			w.log.Info("Recurring tasks materialized", slog.Int("count", spawned))
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("recurrence worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) materialize(ctx context.Context, now time.Time) (int, error) {
+	spawned := 0
+	after := uuid.Nil
+
+	for ctx.Err() == nil {
+		tasks, err := w.repo.GetPendingRecurrences(after, w.batchSize)
+		if err != nil {
+			return spawned, err
+		}
+
+		for _, task := range tasks {
+			next, ok := w.next(task, now)
+			if !ok {
+				continue
+			}
+
+			created, err := w.repo.SpawnNextOccurrence(task.Id, next)
+			if err != nil {
+				w.log.Error("Failed to spawn next occurrence", slog.String("TaskId", task.Id.String()), sl.Error(err))
+				continue
+			}
+			if created {
+				spawned++
+			}
+		}
+
+		if len(tasks) < w.batchSize {
+			break
+		}
+		after = tasks[len(tasks)-1].Id
+	}
+
+	return spawned, nil
+}
+
+// next builds the occurrence that follows task, if it is time to create it.
+// Periods missed while nobody completed the task are skipped, so a DAILY task
+// left open for a week produces one new occurrence for today, not seven.
+// Start and due dates keep their offset from the start of the period.
+func (w *Worker) next(task domain.Task, now time.Time) (domain.Task, bool) {
+	n := task.Occurrence + 1
+	loc := w.location(task)
+
+	start, ok := calendar.Occurrence(task.SeriesStart, task.RepeatTask, n, loc)
+	if !ok {
+		return domain.Task{}, false
+	}
+
+	if task.TaskStatus != domain.DONE && now.Before(start) {
+		return domain.Task{}, false
+	}
+
+	for {
+		following, _ := calendar.Occurrence(task.SeriesStart, task.RepeatTask, n+1, loc)
+		if following.After(now) {
+			break
+		}
+		n, start = n+1, following
+	}
+
+	current, _ := calendar.Occurrence(task.SeriesStart, task.RepeatTask, task.Occurrence, loc)
+	shift := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		shifted := start.Add(t.Sub(current))
+		return &shifted
+	}
+
+	return domain.Task{
+		Id:          uuid.New(),
+		Title:       task.Title,
+		Description: task.Description,
+		TaskStatus:  domain.TODO,
+		Priority:    task.Priority,
+		Version:     1,
+		CreatedAt:   now.UTC(),
+		RepeatTask:  task.RepeatTask,
+		SeriesId:    task.SeriesId,
+		SeriesStart: task.SeriesStart,
+		Occurrence:  n,
+		Timezone:    task.Timezone,
+		StartAt:     shift(task.StartAt),
+		DueAt:       shift(task.DueAt),
+		OwnerId:     task.OwnerId,
+		ProjectId:   task.ProjectId,
+		ParentId:    task.ParentId,
+		Tags:        task.Tags,
+	}, true
+}
+
+// location returns the time zone of the series, or the configured one if the
+// series has none or its zone cannot be loaded.
+func (w *Worker) location(task domain.Task) *time.Location {
+	if task.Timezone == "" {
+		return w.loc
+	}
+
+	if loc, ok := w.zones[task.Timezone]; ok {
+		return loc
+	}
+
+	loc, err := time.LoadLocation(task.Timezone)
+	if err != nil {
+		w.log.Error("Failed to load timezone of series", slog.String("SeriesId", task.SeriesId.String()), sl.Error(err))
+		loc = w.loc
+	}
+	w.zones[task.Timezone] = loc
+	return loc
+}
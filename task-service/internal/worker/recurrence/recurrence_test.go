@@ -0,0 +1,76 @@
+package recurrence
+
+import (
+	"slices"
+	"task-service/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNextKeepsTags(t *testing.T) {
+	w := &Worker{loc: time.UTC}
+
+	start := time.Date(2025, 4, 21, 9, 0, 0, 0, time.UTC)
+	due := start.Add(8 * time.Hour)
+	task := domain.Task{
+		Id:          uuid.New(),
+		Title:       "Water the plants",
+		TaskStatus:  domain.DONE,
+		Priority:    domain.MEDIUM,
+		RepeatTask:  domain.DAILY,
+		SeriesId:    uuid.New(),
+		SeriesStart: start,
+		StartAt:     &start,
+		DueAt:       &due,
+		OwnerId:     uuid.New(),
+		Tags:        []string{"garden", "home"},
+	}
+
+	next, ok := w.next(task, start.Add(time.Hour))
+	if !ok {
+		t.Fatal("next() = false, want the next occurrence of a DONE task")
+	}
+
+	if !slices.Equal(next.Tags, task.Tags) {
+		t.Errorf("next().Tags = %v, want %v", next.Tags, task.Tags)
+	}
+	if next.Occurrence != 1 {
+		t.Errorf("next().Occurrence = %d, want 1", next.Occurrence)
+	}
+	if want := start.AddDate(0, 0, 1); next.StartAt == nil || !next.StartAt.Equal(want) {
+		t.Errorf("next().StartAt = %v, want %v", next.StartAt, want)
+	}
+	if next.SeriesId != task.SeriesId || next.OwnerId != task.OwnerId {
+		t.Errorf("next() left the series %s of owner %s", next.SeriesId, next.OwnerId)
+	}
+}
+
+func TestNextUsesSeriesTimezone(t *testing.T) {
+	w := &Worker{loc: time.UTC, zones: make(map[string]*time.Location)}
+
+	// 9:00 in New York on the day before the clocks go forward.
+	start := time.Date(2025, 3, 8, 14, 0, 0, 0, time.UTC)
+	task := domain.Task{
+		Id:          uuid.New(),
+		TaskStatus:  domain.DONE,
+		RepeatTask:  domain.DAILY,
+		SeriesId:    uuid.New(),
+		SeriesStart: start,
+		StartAt:     &start,
+		Timezone:    "America/New_York",
+	}
+
+	next, ok := w.next(task, start.Add(time.Hour))
+	if !ok {
+		t.Fatal("next() = false, want the next occurrence of a DONE task")
+	}
+
+	if want := time.Date(2025, 3, 9, 13, 0, 0, 0, time.UTC); next.StartAt == nil || !next.StartAt.Equal(want) {
+		t.Errorf("next().StartAt = %v, want %v, 9:00 in New York", next.StartAt, want)
+	}
+	if next.Timezone != task.Timezone {
+		t.Errorf("next().Timezone = %q, want %q", next.Timezone, task.Timezone)
+	}
+}
@@ -0,0 +1,103 @@
+package relay
+
+import (
+	"context"
+	"log/slog"
+	"task-service/domain"
+	"task-service/internal/config"
+	"task-service/internal/lib/logger/sl"
+	"time"
+)
+
+type EventRelayer interface {
+	RelayEvents(ctx context.Context, limit int, gapTimeout time.Duration, publish func(domain.Event) error) (int, error)
+	PurgePublishedEvents(before time.Time) (int, error)
+}
+
+// Publisher delivers a domain event to other services.
+type Publisher interface {
+	Publish(ctx context.Context, event domain.Event) error
+}
+
+// Worker publishes the events queued in the outbox to every publisher and
+// removes published events once they are older than the retention period.
+type Worker struct {
+	log        *slog.Logger
+	repo       EventRelayer
+	publishers []Publisher
+	interval   time.Duration
+	batchSize  int
+	retention  time.Duration
+	gapTimeout time.Duration
+}
+
+func New(log *slog.Logger, repo EventRelayer, cfg config.Outbox, publishers ...Publisher) *Worker {
+	return &Worker{
+		log:        log.With(slog.String("component", "worker/relay")),
+		repo:       repo,
+		publishers: publishers,
+		interval:   cfg.Interval,
+		batchSize:  cfg.BatchSize,
+		retention:  cfg.Retention,
+		gapTimeout: cfg.GapTimeout,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	w.log.Info("relay worker started", slog.String("interval", w.interval.String()))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	lastPurge := time.Time{}
+
+	for {
+		if _, err := w.relay(ctx); err != nil {
+			w.log.Error("Failed to relay events", sl.Error(err))
+		}
+
+		if time.Since(lastPurge) >= time.Hour {
+			purged, err := w.repo.PurgePublishedEvents(time.Now().Add(-w.retention))
+			if err != nil {
+				w.log.Error("Failed to purge published events", sl.Error(err))
+			} else if purged > 0 {
+				w.log.Info("Published events purged", slog.Int("count", purged))
+			}
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("relay worker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay publishes batches until the outbox is drained, so a burst of changes
+// is not held back by the interval.
+func (w *Worker) relay(ctx context.Context) (int, error) {
+	relayed := 0
+
+	for ctx.Err() == nil {
+		n, err := w.repo.RelayEvents(ctx, w.batchSize, w.gapTimeout, func(event domain.Event) error {
+			for _, publisher := range w.publishers {
+				if err := publisher.Publish(ctx, event); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		relayed += n
+		if err != nil {
+			return relayed, err
+		}
+
+		if n < w.batchSize {
+			break
+		}
+	}
+
+	return relayed, nil
+}
@@ -1,5 +1,5 @@
-CREATE TYPE task_status AS ENUM (
-    'TODO',
-    'DONE',
-    'IN_PROGRESS'
+CREATE TYPE task_status AS ENUM (
+    'TODO',
+    'DONE',
+    'IN_PROGRESS'
 );
\ No newline at end of file
@@ -1,7 +1,7 @@
-CREATE TYPE repeatable_task AS ENUM (
-    'NEVER',
-    'DAILY',
-    'WEEKLY',
-    'MONTHLY',
-    'YEARLY'
+CREATE TYPE repeatable_task AS ENUM (
+    'NEVER',
+    'DAILY',
+    'WEEKLY',
+    'MONTHLY',
+    'YEARLY'
 );
\ No newline at end of file
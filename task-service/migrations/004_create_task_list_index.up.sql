@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks(created_at, id);
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_recurrence_pending;
+DROP INDEX IF EXISTS idx_tasks_series_occurrence;
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS next_spawned,
+    DROP COLUMN IF EXISTS occurrence,
+    DROP COLUMN IF EXISTS series_start,
+    DROP COLUMN IF EXISTS series_id;
\ No newline at end of file
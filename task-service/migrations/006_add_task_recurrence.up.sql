@@ -0,0 +1,14 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS series_id UUID,
+    ADD COLUMN IF NOT EXISTS series_start TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS occurrence INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_spawned BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE tasks SET series_id = id, series_start = created_at WHERE series_id IS NULL;
+
+ALTER TABLE tasks
+    ALTER COLUMN series_id SET NOT NULL,
+    ALTER COLUMN series_start SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_series_occurrence ON tasks(series_id, occurrence);
+CREATE INDEX IF NOT EXISTS idx_tasks_recurrence_pending ON tasks(id) WHERE repeatable <> 'NEVER' AND NOT next_spawned;
\ No newline at end of file
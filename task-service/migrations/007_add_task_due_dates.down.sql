@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_overdue;
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS chk_tasks_start_before_due,
+    DROP COLUMN IF EXISTS due_at,
+    DROP COLUMN IF EXISTS start_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS start_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS due_at TIMESTAMP,
+    ADD CONSTRAINT chk_tasks_start_before_due CHECK (start_at IS NULL OR due_at IS NULL OR start_at <= due_at);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_overdue ON tasks(due_at) WHERE status <> 'DONE';
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_status_history;
\ No newline at end of file
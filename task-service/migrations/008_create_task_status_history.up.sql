@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS task_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_status task_status NOT NULL,
+    to_status task_status NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_status_history_task ON task_status_history(task_id, changed_at);
\ No newline at end of file
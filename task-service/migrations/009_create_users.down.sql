@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_owner;
+ALTER TABLE tasks DROP COLUMN IF EXISTS owner_id;
+DROP TABLE IF EXISTS users;
\ No newline at end of file
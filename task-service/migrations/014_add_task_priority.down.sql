@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_owner_priority_due;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
+DROP TYPE IF EXISTS task_priority;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- Declared from the most to the least urgent, so ascending order puts the
+-- most urgent tasks first.
+CREATE TYPE task_priority AS ENUM (
+    'URGENT',
+    'HIGH',
+    'MEDIUM',
+    'LOW'
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS priority task_priority NOT NULL DEFAULT 'MEDIUM';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_owner_priority_due ON tasks(owner_id, priority, COALESCE(due_at, 'infinity'), created_at, id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Incremented on every change of a task, used for optimistic concurrency.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
\ No newline at end of file
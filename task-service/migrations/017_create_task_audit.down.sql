@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_audit;
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- No foreign key to tasks: the log outlives the tasks it describes.
+CREATE TABLE IF NOT EXISTS task_audit (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_audit_task ON task_audit(task_id, id);
+CREATE INDEX IF NOT EXISTS idx_task_audit_owner ON task_audit(owner_id, id);
\ No newline at end of file
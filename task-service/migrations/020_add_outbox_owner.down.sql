@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_owner;
+ALTER TABLE outbox DROP COLUMN IF EXISTS owner_id;
\ No newline at end of file
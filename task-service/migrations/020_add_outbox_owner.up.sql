@@ -0,0 +1,7 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS owner_id UUID;
+
+UPDATE outbox SET owner_id = (payload->>'owner_id')::uuid WHERE owner_id IS NULL;
+
+ALTER TABLE outbox ALTER COLUMN owner_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_owner ON outbox(owner_id, id);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE outbox DROP COLUMN IF EXISTS queued_at;
\ No newline at end of file
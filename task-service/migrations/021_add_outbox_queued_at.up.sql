@@ -0,0 +1,7 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS queued_at TIMESTAMP;
+
+UPDATE outbox SET queued_at = created_at WHERE queued_at IS NULL;
+
+-- NOW() is the start of the transaction, the relay needs the time of the insert.
+ALTER TABLE outbox ALTER COLUMN queued_at SET DEFAULT clock_timestamp();
+ALTER TABLE outbox ALTER COLUMN queued_at SET NOT NULL;
\ No newline at end of file